@@ -0,0 +1,15 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_faas_last_invocation" "nightly" {
+  project_id = 1
+  region_id  = 1
+  namespace  = "ns4test"
+  function   = "testf"
+  trigger    = "nightly"
+}
+
+output "nightly_status" {
+  value = data.gcore_faas_last_invocation.nightly.status
+}
@@ -0,0 +1,18 @@
+data "gcore_fastedge_app" "app" {
+  name = "my-app"
+}
+
+resource "gcore_cdn_rule" "fastedge" {
+  resource_id = 1234
+  name        = "fastedge"
+  rule        = "/api/*"
+  rule_type   = 0
+
+  options {
+    fastedge {
+      on_request_headers {
+        app_id = data.gcore_fastedge_app.app.id
+      }
+    }
+  }
+}
@@ -0,0 +1,40 @@
+# runs the app locally, the plan fails if any expectation is not met
+data "gcore_fastedge_app_check" "auth" {
+  filename = "auth.wasm"
+
+  env = {
+    "LOG_LEVEL" = "debug"
+  }
+
+  secrets = {
+    "TOKEN" = "test-token"
+  }
+
+  rsp_headers = {
+    "x-frame-options" = "DENY"
+  }
+
+  request {
+    url = "https://example.com/private"
+
+    expected_status = 401
+  }
+
+  request {
+    url = "https://example.com/private"
+    headers = {
+      "authorization" = "Bearer test-token"
+    }
+    origin_body = "hello"
+
+    expected_status = 200
+    expected_body   = "^hello$"
+    expected_headers = {
+      "x-frame-options" = "DENY"
+    }
+  }
+}
+
+resource "gcore_fastedge_binary" "auth" {
+  filename = data.gcore_fastedge_app_check.auth.filename
+}
@@ -0,0 +1,7 @@
+data "gcore_fastedge_apps" "enabled" {
+  status = "enabled"
+}
+
+output "enabled_app_names" {
+  value = data.gcore_fastedge_apps.enabled.apps[*].name
+}
@@ -0,0 +1,7 @@
+data "gcore_fastedge_binary" "binary" {
+  binary_id = 42
+}
+
+output "binary_checksum" {
+  value = data.gcore_fastedge_binary.binary.checksum
+}
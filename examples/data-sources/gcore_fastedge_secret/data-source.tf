@@ -0,0 +1,11 @@
+data "gcore_fastedge_secret" "secret" {
+  name = "my-secret"
+}
+
+resource "gcore_fastedge_app" "app" {
+  status = "enabled"
+  binary = 42
+  secrets = {
+    "API_KEY" = data.gcore_fastedge_secret.secret.id
+  }
+}
@@ -0,0 +1,11 @@
+data "gcore_fastedge_template" "template" {
+  name = "my-template"
+}
+
+resource "gcore_fastedge_app" "app" {
+  status   = "enabled"
+  template = data.gcore_fastedge_template.template.id
+  env = {
+    for param in data.gcore_fastedge_template.template.param : param.name => "value" if param.mandatory
+  }
+}
@@ -0,0 +1,21 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "project" {
+  name = "Default"
+}
+
+data "gcore_region" "region" {
+  name = "Luxembourg-2"
+}
+
+data "gcore_lifecyclepolicy_snapshots" "snapshots" {
+  project_id         = data.gcore_project.project.id
+  region_id          = data.gcore_region.region.id
+  lifecyclepolicy_id = 1
+}
+
+output "expiring_snapshots" {
+  value = [for s in data.gcore_lifecyclepolicy_snapshots.snapshots.snapshots : s.id if s.expires_at != ""]
+}
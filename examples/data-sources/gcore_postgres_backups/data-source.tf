@@ -0,0 +1,21 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "project" {
+  name = "Default"
+}
+
+data "gcore_region" "region" {
+  name = "Luxembourg-2"
+}
+
+data "gcore_postgres_backups" "backups" {
+  cluster_name = "my-postgres-cluster"
+  project_id   = data.gcore_project.project.id
+  region_id    = data.gcore_region.region.id
+}
+
+output "latest_backup_id" {
+  value = data.gcore_postgres_backups.backups.backups[length(data.gcore_postgres_backups.backups.backups) - 1].id
+}
@@ -0,0 +1,21 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "project" {
+  name = "Default"
+}
+
+data "gcore_region" "region" {
+  name = "Luxembourg-2"
+}
+
+data "gcore_postgres_configuration" "pg15" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+  version    = "15"
+}
+
+output "restart_required_parameters" {
+  value = [for p in data.gcore_postgres_configuration.pg15.parameters : p.name if p.restart_required]
+}
@@ -0,0 +1,24 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "project" {
+  name = "Default"
+}
+
+data "gcore_region" "region" {
+  name = "Luxembourg-2"
+}
+
+data "gcore_postgres_user" "app" {
+  project_id   = data.gcore_project.project.id
+  region_id    = data.gcore_region.region.id
+  cluster_name = "my-postgres-cluster"
+  name         = "app"
+  database     = "appdb"
+}
+
+output "app_connection_uri" {
+  value     = data.gcore_postgres_user.app.connection_uri
+  sensitive = true
+}
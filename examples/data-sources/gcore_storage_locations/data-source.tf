@@ -0,0 +1,11 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_storage_locations" "s3" {
+  type = "s3"
+}
+
+output "available_s3_locations" {
+  value = [for l in data.gcore_storage_locations.s3.locations : l.name if l.available]
+}
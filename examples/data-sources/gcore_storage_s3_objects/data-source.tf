@@ -0,0 +1,25 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+variable "access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "secret_key" {
+  type      = string
+  sensitive = true
+}
+
+data "gcore_storage_s3_objects" "configs" {
+  storage_id = 1
+  bucket     = "example1bucket2name"
+  prefix     = "config/"
+  access_key = var.access_key
+  secret_key = var.secret_key
+}
+
+output "config_keys" {
+  value = data.gcore_storage_s3_objects.configs.keys
+}
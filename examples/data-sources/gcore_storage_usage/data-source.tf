@@ -0,0 +1,13 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_storage_usage" "october" {
+  from      = "2023-10-01"
+  to        = "2023-10-31"
+  locations = ["s-ed1"]
+}
+
+output "storage_traffic" {
+  value = { for u in data.gcore_storage_usage.october.usage : u.name => u.traffic }
+}
@@ -0,0 +1,12 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_storages" "sftp_mia" {
+  location = "mia"
+  type     = "sftp"
+}
+
+output "sftp_storage_keys" {
+  value = { for s in data.gcore_storages.sftp_mia.storages : s.name => s.ssh_key_id }
+}
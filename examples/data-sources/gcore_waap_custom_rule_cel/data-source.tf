@@ -0,0 +1,38 @@
+provider gcore {
+  permanent_api_token = "768660$.............a43f91f"
+}
+
+# Convert Custom Rule conditions to a CEL expression
+data "gcore_waap_custom_rule_cel" "from_conditions" {
+  conditions {
+    ip {
+      ip_address = "192.168.0.1"
+      negation   = true
+    }
+
+    url {
+      url        = "/admin"
+      match_type = "Contains"
+    }
+  }
+}
+
+resource "gcore_waap_advanced_rule" "migrated" {
+  domain_id = 1234
+  name      = "Block admin"
+  enabled   = true
+  source    = data.gcore_waap_custom_rule_cel.from_conditions.source
+
+  action {
+    block {}
+  }
+}
+
+# Convert a CEL expression back to Custom Rule conditions
+data "gcore_waap_custom_rule_cel" "from_source" {
+  source = "whois.country in ['DE', 'US'] && request.method == 'POST'"
+}
+
+output "unsupported" {
+  value = data.gcore_waap_custom_rule_cel.from_source.unsupported
+}
@@ -29,3 +29,18 @@ EOF
         min_instances = 1
         max_instances = 2
 }
+
+resource "gcore_faas_function" "packaged" {
+        project_id = 1
+        region_id = 1
+        name = "testpackaged"
+        namespace = "ns4test"
+        runtime = "python3.7.12"
+        // zipped by the provider, uploaded again when any file changes
+        source_dir = "${path.module}/function"
+        timeout = 5
+        flavor = "80mCPU-128MB"
+        main_method = "main"
+        min_instances = 1
+        max_instances = 2
+}
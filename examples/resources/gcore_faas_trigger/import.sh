@@ -0,0 +1,2 @@
+# import using <project_id>:<region_id>:<namespace>:<function>:<trigger> format
+terraform import gcore_faas_trigger.test 1:6:ns4test:testf:nightly
@@ -0,0 +1,33 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_faas_trigger" "nightly" {
+  project_id = 1
+  region_id  = 1
+  namespace  = "ns4test"
+  function   = "testf"
+  name       = "nightly"
+  type       = "cron"
+  schedule   = "0 3 * * *"
+  timezone   = "Europe/Luxembourg"
+}
+
+resource "gcore_faas_key" "hook" {
+  project_id = 1
+  region_id  = 1
+  name       = "hook-key"
+}
+
+resource "gcore_faas_trigger" "hook" {
+  project_id     = 1
+  region_id      = 1
+  namespace      = "ns4test"
+  function       = "testf"
+  name           = "hook"
+  type           = "http"
+  path           = "/hooks/deploy"
+  methods        = ["POST"]
+  enable_api_key = true
+  keys           = [gcore_faas_key.hook.name]
+}
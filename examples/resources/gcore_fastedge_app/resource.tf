@@ -39,6 +39,20 @@ resource "gcore_fastedge_app" "app_from_binary" {
   }
 }
 
+# To roll back, replace the binary with the previous_binary_id shown by
+# `terraform state show gcore_fastedge_app.app_with_rollout` and apply.
+resource "gcore_fastedge_app" "app_with_rollout" {
+  status = "enabled"
+  name = "terraform-test3"
+  binary = gcore_fastedge_binary.test_binary.id
+  rollout {
+    health_check_url = "https://terraform-test3.example.com/health"
+    timeout = 120
+    auto_revert = true
+    delete_unused_binaries = true
+  }
+}
+
 resource "gcore_fastedge_template" "test_template" {
   name = "terraform_test_template"
   binary = gcore_fastedge_binary.test_binary.id
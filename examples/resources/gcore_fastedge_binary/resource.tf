@@ -1,3 +1,12 @@
 resource "gcore_fastedge_binary" "test_binary" {
   filename = "test.wasm"
 }
+
+resource "gcore_fastedge_binary" "inline_binary" {
+  content_base64 = filebase64("${path.module}/build/app.wasm")
+}
+
+resource "gcore_fastedge_binary" "ci_binary" {
+  source_url = "https://artifacts.example.com/app/1.2.0/app.wasm"
+  sha256     = "0d7c3a1c5b1f9c2e4e8f5a3b6d2c7e9f1a4b8c3d5e6f7a8b9c0d1e2f3a4b5c6d"
+}
@@ -24,3 +24,20 @@ resource "gcore_fastedge_secret" "test_secret" {
     value = var.app_secret_slot_1
   }
 }
+
+variable "api_token" {
+  description = "Current API token"
+  type        = string
+  sensitive   = true
+}
+
+# new token values are added as slots activated at the given time,
+# the previous token stays valid until the new one is active
+resource "gcore_fastedge_secret" "api_token" {
+  name = "api_token"
+  rotation {
+    value       = var.api_token
+    active_from = "2026-11-01T00:00:00Z"
+    keep        = 1
+  }
+}
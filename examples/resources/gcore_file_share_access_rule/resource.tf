@@ -0,0 +1,25 @@
+provider "gcore" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "project" {
+  name = "Default"
+}
+
+data "gcore_region" "region" {
+  name = "Luxembourg-2"
+}
+
+data "gcore_file_share" "file_share" {
+  name       = "tf-file-share-standard"
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+}
+
+resource "gcore_file_share_access_rule" "app_servers" {
+  project_id    = data.gcore_project.project.id
+  region_id     = data.gcore_region.region.id
+  file_share_id = data.gcore_file_share.file_share.id
+  ip_address    = "10.95.130.0/24"
+  access_mode   = "ro"
+}
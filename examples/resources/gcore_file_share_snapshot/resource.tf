@@ -0,0 +1,47 @@
+provider "gcore" {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "project" {
+  name = "Default"
+}
+
+data "gcore_region" "region" {
+  name = "Luxembourg-2"
+}
+
+resource "gcore_file_share" "file_share" {
+  name       = "tf-file-share"
+  size       = 20
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+  type_name  = "standard"
+  protocol   = "NFS"
+
+  network {
+    network_id = "378ba73d-16c5-4a4e-a755-d9406dd73e63"
+  }
+}
+
+resource "gcore_file_share_snapshot" "snapshot" {
+  project_id    = data.gcore_project.project.id
+  region_id     = data.gcore_region.region.id
+  file_share_id = gcore_file_share.file_share.id
+  name          = "tf-file-share-snapshot"
+  description   = "nightly snapshot"
+}
+
+# new file share restored from the snapshot
+resource "gcore_file_share" "restored" {
+  name        = "tf-file-share-restored"
+  size        = 20
+  project_id  = data.gcore_project.project.id
+  region_id   = data.gcore_region.region.id
+  type_name   = "standard"
+  protocol    = "NFS"
+  snapshot_id = gcore_file_share_snapshot.snapshot.id
+
+  network {
+    network_id = "378ba73d-16c5-4a4e-a755-d9406dd73e63"
+  }
+}
@@ -0,0 +1,18 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_laas_credentials" "creds" {
+  region_id  = 1
+  project_id = 1
+
+  // change any value to generate a new password
+  rotation_triggers = {
+    rotated_at = "2024-01-01"
+  }
+}
+
+output "kafka_password" {
+  value     = gcore_laas_credentials.creds.password
+  sensitive = true
+}
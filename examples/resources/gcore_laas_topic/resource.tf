@@ -6,5 +6,7 @@ resource "gcore_laas_topic" "test" {
   region_id  = 1
   project_id = 1
 
-  name              = "test"
+  name         = "test"
+  partitions   = 3
+  retention_ms = 604800000
 }
@@ -28,3 +28,45 @@ resource "gcore_lifecyclepolicy" "lp" {
     }
   }
 }
+
+# snapshots of all volumes tagged with backup = daily, copied to another region
+resource "gcore_lifecyclepolicy" "tagged_volumes" {
+  project_id = 1
+  region_id  = 1
+  name       = "daily volume snapshots"
+  action     = "volume_snapshot"
+  volume_metadata = {
+    backup = "daily"
+  }
+  schedule {
+    max_quantity = 7
+    cron {
+      hour = "2"
+    }
+  }
+  cross_region_copy {
+    region_id    = 76
+    max_quantity = 3
+  }
+}
+
+# consistent backups of all volumes attached to the instance
+resource "gcore_lifecyclepolicy" "instance_backup" {
+  project_id = 1
+  region_id  = 1
+  name       = "instance backup"
+  action     = "instance_backup"
+  instance {
+    id = "a9e2b7c4-39c8-4a3b-8b3e-6b1c5e0d2f11"
+  }
+  schedule {
+    max_quantity           = 14
+    resource_name_template = "backup of the instance {instance_id}"
+    interval {
+      days = 1
+    }
+    retention_time {
+      weeks = 2
+    }
+  }
+}
@@ -23,6 +23,12 @@ resource "gcore_postgres_cluster" "basic_cluster" {
 
   pg_config {
     version = "15"
+
+    # validated against data.gcore_postgres_configuration
+    parameters = {
+      max_connections   = "200"
+      statement_timeout = "5min"
+    }
   }
 
   storage {
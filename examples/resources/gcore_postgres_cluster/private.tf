@@ -0,0 +1,69 @@
+# PostgreSQL cluster in a private network with read replicas
+resource "gcore_network" "db_network" {
+  name       = "db-network"
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+}
+
+resource "gcore_subnet" "db_subnet" {
+  name       = "db-subnet"
+  cidr       = "192.168.20.0/24"
+  network_id = gcore_network.db_network.id
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+}
+
+resource "gcore_postgres_cluster" "private_cluster" {
+  name       = "private-pg-cluster"
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  flavor {
+    cpu    = 2
+    memory = 4
+  }
+
+  read_replicas {
+    count = 2
+
+    flavor {
+      cpu    = 1
+      memory = 2
+    }
+  }
+
+  database {
+    name  = "appdb"
+    owner = "app"
+  }
+
+  network {
+    network_type = "private"
+    network_id   = gcore_network.db_network.id
+    subnet_id    = gcore_subnet.db_subnet.id
+  }
+
+  pg_config {
+    version = "15"
+  }
+
+  storage {
+    size = 50
+    type = "ssd-hiiops"
+  }
+
+  user {
+    name = "app"
+    role_attributes = [
+      "LOGIN",
+    ]
+  }
+}
+
+output "postgres_primary" {
+  value = gcore_postgres_cluster.private_cluster.primary_connection_string
+}
+
+output "postgres_replicas" {
+  value = gcore_postgres_cluster.private_cluster.replica_connection_string
+}
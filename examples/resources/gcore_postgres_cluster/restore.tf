@@ -0,0 +1,56 @@
+# PostgreSQL cluster restored from a point in time of another cluster
+data "gcore_postgres_backups" "ha_cluster" {
+  cluster_name = gcore_postgres_cluster.ha_cluster.name
+  project_id   = data.gcore_project.project.id
+  region_id    = data.gcore_region.region.id
+}
+
+resource "gcore_postgres_cluster" "restored_cluster" {
+  name       = "restored-pg-cluster"
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  restore_from {
+    cluster_name  = gcore_postgres_cluster.ha_cluster.name
+    point_in_time = data.gcore_postgres_backups.ha_cluster.backups[length(data.gcore_postgres_backups.ha_cluster.backups) - 1].recovery_window_end
+  }
+
+  backup {
+    schedule       = "0 3 * * *"
+    retention_days = 14
+  }
+
+  flavor {
+    cpu    = 4
+    memory = 8
+  }
+
+  database {
+    name  = "proddb"
+    owner = "produser"
+  }
+
+  network {
+    acl = [
+      "10.0.0.0/8"
+    ]
+    network_type = "public"
+  }
+
+  pg_config {
+    version = "15"
+  }
+
+  storage {
+    size = 100
+    type = "ssd-hiiops"
+  }
+
+  user {
+    name = "produser"
+    role_attributes = [
+      "LOGIN",
+      "CREATEDB"
+    ]
+  }
+}
@@ -0,0 +1,30 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "project" {
+  name = "Default"
+}
+
+data "gcore_region" "region" {
+  name = "Luxembourg-2"
+}
+
+# the user is declared in the user block of the cluster
+resource "gcore_postgres_user" "app" {
+  project_id   = data.gcore_project.project.id
+  region_id    = data.gcore_region.region.id
+  cluster_name = "my-postgres-cluster"
+  name         = "app"
+  database     = "appdb"
+
+  # a new password is generated every time the value changes
+  password_rotation_triggers = {
+    rotated = "2024-05"
+  }
+}
+
+output "app_connection_uri" {
+  value     = gcore_postgres_user.app.connection_uri
+  sensitive = true
+}
@@ -0,0 +1,27 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_snapshot" "snapshot" {
+  project_id = 1
+  region_id  = 1
+  name       = "snapshot example"
+  volume_id  = "28e9edcb-1593-41fe-971b-da729c6ec301"
+}
+
+resource "gcore_snapshot_copy" "dr" {
+  project_id            = 1
+  region_id             = 1
+  source_snapshot_id    = gcore_snapshot.snapshot.id
+  destination_region_id = 76
+  name                  = "snapshot example dr copy"
+}
+
+# restore the volume from the copy in the destination region
+resource "gcore_volume" "dr" {
+  project_id  = 1
+  region_id   = gcore_snapshot_copy.dr.destination_region_id
+  name        = "volume restored in dr region"
+  snapshot_id = gcore_snapshot_copy.dr.id
+  size        = gcore_snapshot_copy.dr.size
+}
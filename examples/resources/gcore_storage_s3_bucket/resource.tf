@@ -6,3 +6,30 @@ resource "gcore_storage_s3_bucket" "example_s3_bucket" {
   name       = "example1bucket2name"
   storage_id = 1
 }
+
+resource "gcore_storage_s3" "example_s3" {
+  name     = "example"
+  location = "s-region-1"
+}
+
+resource "gcore_storage_s3_bucket" "example_s3_bucket_settings" {
+  name       = "example1bucket2assets"
+  storage_id = gcore_storage_s3.example_s3.storage_id
+  access_key = gcore_storage_s3.example_s3.generated_access_key
+  secret_key = gcore_storage_s3.example_s3.generated_secret_key
+
+  cors_rule {
+    allowed_origins = ["https://example.com"]
+    allowed_methods = ["GET", "HEAD"]
+    max_age_seconds = 3600
+  }
+
+  lifecycle_rule {
+    prefix          = "tmp/"
+    expiration_days = 7
+  }
+
+  policy {
+    type = "public-read"
+  }
+}
@@ -0,0 +1,28 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_storage_s3" "example_s3" {
+  name     = "example"
+  location = "s-region-1"
+}
+
+# keys are rotated every 30 days, or when the rotation trigger is changed
+resource "gcore_storage_s3_credentials" "example_s3" {
+  storage_id      = gcore_storage_s3.example_s3.storage_id
+  rotation_period = "720h"
+  triggers = {
+    rotation = "1"
+  }
+}
+
+resource "gcore_storage_s3_bucket" "example_s3_bucket" {
+  name       = "example1bucket2name"
+  storage_id = gcore_storage_s3.example_s3.storage_id
+  access_key = gcore_storage_s3_credentials.example_s3.access_key
+  secret_key = gcore_storage_s3_credentials.example_s3.secret_key
+
+  lifecycle_rule {
+    expiration_days = 30
+  }
+}
@@ -0,0 +1,33 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_storage_s3" "example_s3" {
+  name     = "example"
+  location = "s-region-1"
+}
+
+resource "gcore_storage_s3_bucket" "example_s3_bucket" {
+  name       = "example1bucket2name"
+  storage_id = gcore_storage_s3.example_s3.storage_id
+}
+
+resource "gcore_storage_s3_object" "index" {
+  storage_id    = gcore_storage_s3.example_s3.storage_id
+  bucket        = gcore_storage_s3_bucket.example_s3_bucket.name
+  key           = "index.html"
+  source        = "${path.module}/site/index.html"
+  cache_control = "max-age=300"
+  access_key    = gcore_storage_s3.example_s3.generated_access_key
+  secret_key    = gcore_storage_s3.example_s3.generated_secret_key
+}
+
+resource "gcore_storage_s3_object" "config" {
+  storage_id   = gcore_storage_s3.example_s3.storage_id
+  bucket       = gcore_storage_s3_bucket.example_s3_bucket.name
+  key          = "config/app.json"
+  content      = jsonencode({ feature_flags = { new_ui = true } })
+  content_type = "application/json"
+  access_key   = gcore_storage_s3.example_s3.generated_access_key
+  secret_key   = gcore_storage_s3.example_s3.generated_secret_key
+}
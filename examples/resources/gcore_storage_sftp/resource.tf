@@ -7,3 +7,13 @@ resource "gcore_storage_sftp" "example_sftp" {
   location   = "mia"
   ssh_key_id = [199]
 }
+
+# generated password, rotated when the trigger is changed
+resource "gcore_storage_sftp" "example_sftp_generated" {
+  name               = "example-generated"
+  location           = "mia"
+  generated_password = true
+  password_rotation_triggers = {
+    rotation = "2024-06"
+  }
+}
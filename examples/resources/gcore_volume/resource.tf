@@ -9,3 +9,13 @@ resource "gcore_volume" "volume" {
   region_id  = 1
   project_id = 1
 }
+
+# revert the volume in place to its latest snapshot
+resource "gcore_volume" "reverted" {
+  name                  = "volume_example_reverted"
+  type_name             = "standard"
+  size                  = 1
+  region_id             = 1
+  project_id            = 1
+  revert_to_snapshot_id = "726ecfcc-7fd0-4e30-a86e-7892524aa483"
+}
@@ -36,3 +36,19 @@ resource "gcore_waap_domain" "domain" {
     traffic_scan_interval_hours = 6
   }
 }
+
+resource "gcore_cdn_resource" "onboarded" {
+  cname  = "shop.example.com"
+  origin = "origin.example.com"
+
+  # WAAP protection is turned on and off here, the domain appears once it is enabled
+  options {
+    waap { value = true }
+  }
+}
+
+# waits for the domain of the CDN resource to be onboarded and manages its settings
+resource "gcore_waap_domain" "onboarded" {
+  cdn_resource_id = gcore_cdn_resource.onboarded.id
+  status          = "active"
+}
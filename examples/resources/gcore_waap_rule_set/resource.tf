@@ -0,0 +1,56 @@
+provider gcore {
+  permanent_api_token = "768660$.............a43f91f"
+}
+
+resource "gcore_waap_rule_set" "baseline" {
+  name = "baseline"
+
+  custom_rule {
+    name    = "Block scanners"
+    enabled = true
+
+    action {
+      block {
+        status_code = 403
+      }
+    }
+
+    conditions {
+      user_agent {
+        user_agent = "sqlmap"
+        match_type = "Contains"
+      }
+    }
+  }
+
+  firewall_rule {
+    name    = "Block office range"
+    enabled = true
+
+    action {
+      block {
+        status_code     = 403
+        action_duration = "12h"
+      }
+    }
+
+    conditions {
+      ip_range {
+        lower_bound = "192.168.1.1"
+        upper_bound = "192.168.1.7"
+      }
+    }
+  }
+
+  advanced_rule {
+    name    = "Block IP"
+    enabled = true
+    source  = "request.ip == '117.20.32.55'"
+
+    action {
+      block {
+        status_code = 403
+      }
+    }
+  }
+}
@@ -0,0 +1,8 @@
+provider gcore {
+  permanent_api_token = "768660$.............a43f91f"
+}
+
+resource "gcore_waap_rule_set_attachment" "baseline" {
+  rule_set   = gcore_waap_rule_set.baseline.definition
+  domain_ids = [for domain in gcore_waap_domain.domains : domain.id]
+}
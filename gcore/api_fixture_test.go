@@ -0,0 +1,79 @@
+package gcore
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+// apiFixture is an API call expected by newAPIFixtureClient and the response to it
+type apiFixture struct {
+	method string
+	path   string
+	// query is the expected raw query, not checked when empty
+	query string
+	// request is the expected JSON body, empty for calls without a body
+	request  string
+	status   int
+	response string
+}
+
+// newAPIFixtureClient returns a client of a server answering each call with its fixture,
+// every fixture must be called once and request bodies must match as JSON
+func newAPIFixtureClient(t *testing.T, fixtures ...apiFixture) *gcorecloud.ServiceClient {
+	t.Helper()
+	called := make([]bool, len(fixtures))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i, f := range fixtures {
+			if called[i] || f.method != r.Method || f.path != r.URL.Path {
+				continue
+			}
+			called[i] = true
+			if f.query != "" && f.query != r.URL.RawQuery {
+				t.Errorf("%s %s: query %s, want %s", r.Method, r.URL.Path, r.URL.RawQuery, f.query)
+			}
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Errorf("%s %s: reading body: %v", r.Method, r.URL.Path, err)
+			}
+			if !equalJSON(t, f.request, string(body)) {
+				t.Errorf("%s %s: request body %s, want %s", r.Method, r.URL.Path, body, f.request)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(f.status)
+			_, _ = w.Write([]byte(f.response))
+			return
+		}
+		t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(func() {
+		server.Close()
+		for i, f := range fixtures {
+			if !called[i] {
+				t.Errorf("%s %s was not called", f.method, f.path)
+			}
+		}
+	})
+	return &gcorecloud.ServiceClient{ProviderClient: &gcorecloud.ProviderClient{}, Endpoint: server.URL + "/"}
+}
+
+func equalJSON(t *testing.T, want, got string) bool {
+	if want == "" || got == "" {
+		return want == got
+	}
+	var w, g any
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Errorf("invalid fixture %s: %v", want, err)
+		return false
+	}
+	if err := json.Unmarshal([]byte(got), &g); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(w, g)
+}
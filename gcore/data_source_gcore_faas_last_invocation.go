@@ -0,0 +1,137 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFaaSLastInvocation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFaaSLastInvocationRead,
+		Description: "Represent the latest invocation of a FaaS function, through its endpoint or a trigger",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"namespace": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Namespace of the function",
+			},
+			"function": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"trigger": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the trigger, to only consider its invocations",
+			},
+			"invocation_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Empty if the function was not invoked yet",
+			},
+			"invoked_by_trigger": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the trigger of the invocation, empty for calls of the function endpoint",
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status_code": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"error": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"duration_ms": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"started_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceFaaSLastInvocationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start FaaS last invocation reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, faasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nsName := d.Get("namespace").(string)
+	fName := d.Get("function").(string)
+	trigger := d.Get("trigger").(string)
+	invocation, err := lastFaaSInvocation(client, nsName, fName, trigger)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s_%s_%s", trigger, fName, nsName))
+	if invocation == nil {
+		log.Printf("[DEBUG] FaaS function %s in %s was not invoked yet", fName, nsName)
+		return nil
+	}
+	d.Set("invocation_id", invocation.ID)
+	d.Set("invoked_by_trigger", invocation.Trigger)
+	d.Set("status", invocation.Status)
+	d.Set("status_code", invocation.StatusCode)
+	d.Set("error", invocation.Error)
+	d.Set("duration_ms", invocation.DurationMs)
+	d.Set("started_at", invocation.StartedAt.Format(time.RFC3339))
+
+	log.Println("[DEBUG] Finish FaaS last invocation reading")
+	return nil
+}
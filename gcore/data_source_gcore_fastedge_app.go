@@ -0,0 +1,127 @@
+package gcore
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastEdgeApp() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastEdgeAppRead,
+		Description: "FastEdge application. Environment variable and secret values are not exposed.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Application name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"url": {
+				Description: "Application URL.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Status code. Possible values are: enabled, disabled, suspended.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"binary": {
+				Description: "WebAssembly binary id.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"template": {
+				Description: "Application template id.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"env_keys": {
+				Description: "Environment variable names.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"secret_keys": {
+				Description: "Secret variable names.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"debug": {
+				Description: "Logging enabled.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"comment": {
+				Description: "Application comment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceFastEdgeAppRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] Start FastEdge app data source read")
+	config := m.(*Config)
+	client := config.FastEdgeClient
+
+	name := d.Get("name").(string)
+	idRsp, err := client.GetAppIdByNameWithResponse(ctx, name)
+	if err != nil {
+		return diag.Errorf("calling GetAppIdByName API: %v", err)
+	}
+	if !statusOK(idRsp.StatusCode()) {
+		if idRsp.StatusCode() == http.StatusNotFound {
+			return diag.Errorf("FastEdge app %q was not found", name)
+		}
+		return diag.Errorf("calling GetAppIdByName API: %s", extractErrorMessage(idRsp.Body))
+	}
+	id := *idRsp.JSON200
+
+	rsp, err := client.GetAppWithResponse(ctx, id)
+	if err != nil {
+		return diag.Errorf("calling GetApp API: %v", err)
+	}
+	if !statusOK(rsp.StatusCode()) {
+		return diag.Errorf("calling GetApp API: %s", extractErrorMessage(rsp.Body))
+	}
+
+	app := rsp.JSON200
+	d.SetId(strconv.FormatInt(id, 10))
+	setField(d, "url", app.Url)
+	setField(d, "binary", app.Binary)
+	setField(d, "template", app.Template)
+	setField(d, "debug", app.Debug)
+	setField(d, "comment", app.Comment)
+	if app.Status != nil {
+		d.Set("status", statusToString(*app.Status))
+	}
+
+	envKeys := []string{}
+	if app.Env != nil {
+		for k := range *app.Env {
+			envKeys = append(envKeys, k)
+		}
+	}
+	sort.Strings(envKeys)
+	d.Set("env_keys", envKeys)
+
+	secretKeys := []string{}
+	if app.Secrets != nil {
+		for k := range *app.Secrets {
+			secretKeys = append(secretKeys, k)
+		}
+	}
+	sort.Strings(secretKeys)
+	d.Set("secret_keys", secretKeys)
+
+	log.Printf("[DEBUG] Finish FastEdge app data source read (id=%d)\n", id)
+	return nil
+}
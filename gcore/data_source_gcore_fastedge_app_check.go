@@ -0,0 +1,250 @@
+package gcore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/terraform-providers/terraform-provider-gcore/gcore/fastedgelocal"
+)
+
+// fastEdgeAppCheckTimeout limits the time a single sample request may run
+const fastEdgeAppCheckTimeout = 10 * time.Second
+
+func dataSourceFastEdgeAppCheck() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastEdgeAppCheckRead,
+		Description: "Runs a FastEdge application locally, without uploading it, and checks its responses to sample requests. " +
+			"Env, secrets and response headers are applied the same way FastEdge does. " +
+			"Only proxy-wasm modules are supported, WebAssembly components (wasi-http apps) are rejected and have to be tested on a deployed `gcore_fastedge_app`. " +
+			"Failed expectations are reported as errors, so the configuration can be validated offline in CI.",
+		Schema: map[string]*schema.Schema{
+			"filename": {
+				Description:  "WebAssembly binary file to run.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"filename", "content_base64"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"content_base64": {
+				Description:  "Base64-encoded WebAssembly binary to run.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"filename", "content_base64"},
+				ValidateFunc: validation.StringIsBase64,
+			},
+			"env": {
+				Description: "Environment variables.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"secrets": {
+				Description: "Secret values, by secret variable name.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rsp_headers": {
+				Description: "Response headers.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"request": {
+				Description: "Sample requests.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"method": {
+							Description: "Request method.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     http.MethodGet,
+						},
+						"url": {
+							Description:  "Request URL.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "http://localhost/",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"headers": {
+							Description: "Request headers.",
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"body": {
+							Description: "Request body.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"origin_status": {
+							Description: "Status of the origin response, used if the application passes the request through.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     http.StatusOK,
+						},
+						"origin_headers": {
+							Description: "Headers of the origin response.",
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"origin_body": {
+							Description: "Body of the origin response.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"expected_status": {
+							Description: "Expected response status.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"expected_body": {
+							Description:  "Regular expression the response body must match.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+						},
+						"expected_headers": {
+							Description: "Expected response header values. Header names are lowercase.",
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"status": {
+							Description: "Response status.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"response_headers": {
+							Description: "Response headers. Header names are lowercase.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"response_body": {
+							Description: "Response body.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"log": {
+							Description: "Application log output.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFastEdgeAppCheckRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] Start FastEdge app check")
+
+	payload, err := loadLocalFastEdgeBinary(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	app := fastedgelocal.App{
+		Binary:     payload,
+		Env:        convertStringMap(d.Get("env").(map[string]any)),
+		Secrets:    convertStringMap(d.Get("secrets").(map[string]any)),
+		RspHeaders: convertStringMap(d.Get("rsp_headers").(map[string]any)),
+	}
+
+	var diags diag.Diagnostics
+	requests := d.Get("request").([]any)
+	for i, item := range requests {
+		r := item.(map[string]any)
+		req := fastedgelocal.Request{
+			Method:  r["method"].(string),
+			URL:     r["url"].(string),
+			Headers: convertStringMap(r["headers"].(map[string]any)),
+			Body:    []byte(r["body"].(string)),
+		}
+		origin := &fastedgelocal.Response{
+			Status:  r["origin_status"].(int),
+			Headers: convertStringMap(r["origin_headers"].(map[string]any)),
+			Body:    []byte(r["origin_body"].(string)),
+		}
+		summary := fmt.Sprintf("request %d (%s %s)", i, req.Method, req.URL)
+
+		var logs bytes.Buffer
+		app.Log = &logs
+		runCtx, cancel := context.WithTimeout(ctx, fastEdgeAppCheckTimeout)
+		rsp, err := fastedgelocal.Run(runCtx, app, req, func(*fastedgelocal.Request) *fastedgelocal.Response { return origin })
+		cancel()
+		r["log"] = logs.String()
+		if errors.Is(err, fastedgelocal.ErrComponent) || errors.Is(err, fastedgelocal.ErrNotProxyWasm) {
+			// The binary is the same for every request, so there is no point in running the rest
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Unsupported FastEdge binary",
+				Detail: fmt.Sprintf("%s. gcore_fastedge_app_check only runs proxy-wasm modules, "+
+					"wasi-http apps have to be tested on a deployed gcore_fastedge_app.", err),
+			}}
+		}
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{Severity: diag.Error, Summary: summary + " failed", Detail: err.Error()})
+			continue
+		}
+
+		r["status"] = rsp.Status
+		r["response_headers"] = rsp.Headers
+		r["response_body"] = string(rsp.Body)
+		diags = append(diags, checkFastEdgeAppResponse(summary, r, rsp)...)
+	}
+
+	d.SetId(sha256Checksum(payload))
+	if err := d.Set("request", requests); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finish FastEdge app check (%d requests, %d errors)\n", len(requests), len(diags))
+	return diags
+}
+
+// checkFastEdgeAppResponse compares the response with the expectations of the request block
+func checkFastEdgeAppResponse(summary string, r map[string]any, rsp *fastedgelocal.Response) diag.Diagnostics {
+	var diags diag.Diagnostics
+	fail := func(format string, args ...any) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary + ": unexpected response",
+			Detail:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	if expected := r["expected_status"].(int); expected != 0 && expected != rsp.Status {
+		fail("expected status %d, got %d", expected, rsp.Status)
+	}
+	if expected := r["expected_body"].(string); expected != "" {
+		if !regexp.MustCompile(expected).Match(rsp.Body) {
+			fail("expected body to match %q, got %q", expected, rsp.Body)
+		}
+	}
+	for k, v := range convertStringMap(r["expected_headers"].(map[string]any)) {
+		if got, ok := rsp.Headers[k]; !ok {
+			fail("expected header %s: %s, header is missing", k, v)
+		} else if got != v {
+			fail("expected header %s: %s, got %s", k, v, got)
+		}
+	}
+	return diags
+}
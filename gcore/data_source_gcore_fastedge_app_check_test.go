@@ -0,0 +1,28 @@
+package gcore
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceFastEdgeAppCheckComponent(t *testing.T) {
+	// Binary header of a WebAssembly component: magic, version 0x0d, layer 1
+	component := []byte{0x00, 0x61, 0x73, 0x6d, 0x0d, 0x00, 0x01, 0x00}
+	d := schema.TestResourceDataRaw(t, dataSourceFastEdgeAppCheck().Schema, map[string]interface{}{
+		"content_base64": base64.StdEncoding.EncodeToString(component),
+		"request": []interface{}{
+			map[string]interface{}{"url": "http://example.com/"},
+			map[string]interface{}{"url": "http://example.com/other"},
+		},
+	})
+
+	diags := dataSourceFastEdgeAppCheckRead(context.Background(), d, nil)
+	if len(diags) != 1 || diags[0].Summary != "Unsupported FastEdge binary" ||
+		!strings.Contains(diags[0].Detail, "only runs proxy-wasm modules") {
+		t.Errorf("unexpected diagnostics: %+v", diags)
+	}
+}
@@ -0,0 +1,161 @@
+package gcore
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+)
+
+func dataSourceFastEdgeApps() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastEdgeAppsRead,
+		Description: "List of FastEdge applications matching the filters.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Filter by application name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description:  "Filter by status. Possible values are: enabled, disabled, suspended.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled", "suspended"}, false),
+			},
+			"binary": {
+				Description: "Filter by WebAssembly binary id.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"template": {
+				Description: "Filter by application template id.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"api_type": {
+				Description:  "Filter by Wasm API type. Possible values are: wasi-http, proxy-wasm.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(sdk.ListAppsParamsApiTypeWasiHttp), string(sdk.ListAppsParamsApiTypeProxyWasm)}, false),
+			},
+			"apps": {
+				Description: "Applications matching the filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Application id.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Application name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Status code. Possible values are: enabled, disabled, suspended.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"binary": {
+							Description: "WebAssembly binary id.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"template": {
+							Description: "Application template id.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"api_type": {
+							Description: "Wasm API type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"comment": {
+							Description: "Application comment.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFastEdgeAppsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] Start FastEdge apps data source read")
+	config := m.(*Config)
+	client := config.FastEdgeClient
+
+	limit := fastEdgeListPageSize
+	params := sdk.ListAppsParams{Limit: &limit}
+	if v, ok := d.GetOk("name"); ok {
+		params.Name = ptr(v.(string))
+	}
+	if v, ok := d.GetOk("status"); ok {
+		params.Status = ptr(statusToInt(v.(string)))
+	}
+	if v, ok := d.GetOk("binary"); ok {
+		params.Binary = ptr(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("template"); ok {
+		params.Template = ptr(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("api_type"); ok {
+		params.ApiType = ptr(sdk.ListAppsParamsApiType(v.(string)))
+	}
+
+	apps := []any{}
+	ids := []string{}
+	for offset := 0; ; offset += limit {
+		params.Offset = ptr(offset)
+		rsp, err := client.ListAppsWithResponse(ctx, &params)
+		if err != nil {
+			return diag.Errorf("calling ListApps API: %v", err)
+		}
+		if !statusOK(rsp.StatusCode()) {
+			return diag.Errorf("calling ListApps API: %s", extractErrorMessage(rsp.Body))
+		}
+		for _, app := range rsp.JSON200.Apps {
+			item := map[string]any{
+				"id":       app.Id,
+				"name":     app.Name,
+				"status":   statusToString(app.Status),
+				"binary":   app.Binary,
+				"api_type": app.ApiType,
+				"comment":  "",
+				"template": 0,
+			}
+			if app.Comment != nil {
+				item["comment"] = *app.Comment
+			}
+			if app.Template != nil {
+				item["template"] = *app.Template
+			}
+			apps = append(apps, item)
+			ids = append(ids, strconv.FormatInt(app.Id, 10))
+		}
+		if len(rsp.JSON200.Apps) < limit {
+			break
+		}
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	if err := d.Set("apps", apps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finish FastEdge apps data source read (%d apps)\n", len(apps))
+	return nil
+}
@@ -0,0 +1,93 @@
+package gcore
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastEdgeBinary() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastEdgeBinaryRead,
+		Description: "WebAssembly binary metadata.",
+		Schema: map[string]*schema.Schema{
+			"binary_id": {
+				Description:  "WebAssembly binary id.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"binary_id", "checksum"},
+			},
+			"checksum": {
+				Description:  "Binary checksum, as returned by `gcore_fastedge_binary`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"binary_id", "checksum"},
+			},
+			"api_type": {
+				Description: "Wasm API type.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Binary status code.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"unref_since": {
+				Description: "Time since the binary is not referenced by any application.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceFastEdgeBinaryRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] Start FastEdge binary data source read")
+	config := m.(*Config)
+	client := config.FastEdgeClient
+
+	id := int64(d.Get("binary_id").(int))
+	if checksum := d.Get("checksum").(string); id == 0 {
+		rsp, err := client.ListBinariesWithResponse(ctx)
+		if err != nil {
+			return diag.Errorf("calling ListBinaries API: %v", err)
+		}
+		if !statusOK(rsp.StatusCode()) {
+			return diag.Errorf("calling ListBinaries API: %s", extractErrorMessage(rsp.Body))
+		}
+		for _, binary := range rsp.JSON200.Binaries {
+			if binary.Checksum != nil && *binary.Checksum == checksum {
+				id = binary.Id
+				break
+			}
+		}
+		if id == 0 {
+			return diag.Errorf("FastEdge binary with checksum %s was not found", checksum)
+		}
+	}
+
+	rsp, err := client.GetBinaryWithResponse(ctx, id)
+	if err != nil {
+		return diag.Errorf("calling GetBinary API: %v", err)
+	}
+	if !statusOK(rsp.StatusCode()) {
+		return diag.Errorf("calling GetBinary API: %s", extractErrorMessage(rsp.Body))
+	}
+
+	binary := rsp.JSON200
+	d.SetId(strconv.FormatInt(binary.Id, 10))
+	d.Set("binary_id", binary.Id)
+	d.Set("api_type", binary.ApiType)
+	d.Set("status", binary.Status)
+	setField(d, "checksum", binary.Checksum)
+	setField(d, "unref_since", binary.UnrefSince)
+
+	log.Printf("[DEBUG] Finish FastEdge binary data source read (id=%d)\n", binary.Id)
+	return nil
+}
@@ -0,0 +1,97 @@
+package gcore
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+)
+
+func dataSourceFastEdgeSecret() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastEdgeSecretRead,
+		Description: "FastEdge secret metadata. Secret values are not exposed.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Secret name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"comment": {
+				Description: "Secret description.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"app_count": {
+				Description: "Number of applications using the secret.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"slot": {
+				Description: "Secret slots.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Secret slot id, often used as 'effective from' timestamp.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"checksum": {
+							Description: "Slot value checksum.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFastEdgeSecretRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] Start FastEdge secret data source read")
+	config := m.(*Config)
+	client := config.FastEdgeClient
+
+	name := d.Get("name").(string)
+	listRsp, err := client.ListSecretsWithResponse(ctx, &sdk.ListSecretsParams{SecretName: &name})
+	if err != nil {
+		return diag.Errorf("calling ListSecrets API: %v", err)
+	}
+	if !statusOK(listRsp.StatusCode()) {
+		return diag.Errorf("calling ListSecrets API: %s", extractErrorMessage(listRsp.Body))
+	}
+	var id int64
+	for _, secret := range listRsp.JSON200.Secrets {
+		if secret.Name == name && secret.Id != nil {
+			id = *secret.Id
+			break
+		}
+	}
+	if id == 0 {
+		return diag.Errorf("FastEdge secret %q was not found", name)
+	}
+
+	rsp, err := client.GetSecretWithResponse(ctx, id)
+	if err != nil {
+		return diag.Errorf("calling GetSecret API: %v", err)
+	}
+	if !statusOK(rsp.StatusCode()) {
+		return diag.Errorf("calling GetSecret API: %s", extractErrorMessage(rsp.Body))
+	}
+
+	secret := rsp.JSON200
+	d.SetId(strconv.FormatInt(id, 10))
+	setField(d, "comment", secret.Comment)
+	setField(d, "app_count", secret.AppCount)
+	d.Set("slot", parseSecretSlots(secret.SecretSlots))
+
+	log.Printf("[DEBUG] Finish FastEdge secret data source read (id=%d)\n", id)
+	return nil
+}
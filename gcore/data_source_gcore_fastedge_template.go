@@ -0,0 +1,149 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+)
+
+const fastEdgeListPageSize = 100
+
+func dataSourceFastEdgeTemplate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastEdgeTemplateRead,
+		Description: "FastEdge application template.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Template name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"binary": {
+				Description: "WebAssembly binary id.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"short_descr": {
+				Description: "Short description.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"long_descr": {
+				Description: "Instruction how to configure the template.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"owned": {
+				Description: "Whether the template is owned by the current client.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"param": {
+				Description: "Template parameters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Parameter name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Parameter type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"mandatory": {
+							Description: "Is parameter mandatory.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"descr": {
+							Description: "Parameter description.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"metadata": {
+							Description: "Parameter metadata.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFastEdgeTemplateRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	log.Println("[DEBUG] Start FastEdge template data source read")
+	config := m.(*Config)
+	client := config.FastEdgeClient
+
+	name := d.Get("name").(string)
+	id, err := findFastEdgeTemplateByName(ctx, client, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if id == 0 {
+		return diag.Errorf("FastEdge template %q was not found", name)
+	}
+
+	rsp, err := client.GetTemplateWithResponse(ctx, id)
+	if err != nil {
+		return diag.Errorf("calling GetTemplate API: %v", err)
+	}
+	if !statusOK(rsp.StatusCode()) {
+		return diag.Errorf("calling GetTemplate API: %s", extractErrorMessage(rsp.Body))
+	}
+
+	template := rsp.JSON200
+	d.SetId(strconv.FormatInt(id, 10))
+	d.Set("binary", template.BinaryId)
+	d.Set("owned", template.Owned)
+	setField(d, "short_descr", template.ShortDescr)
+	setField(d, "long_descr", template.LongDescr)
+	params := make([]any, len(template.Params))
+	for i, param := range template.Params {
+		params[i] = map[string]any{
+			"name":      param.Name,
+			"type":      param.DataType,
+			"mandatory": param.Mandatory,
+			"descr":     param.Descr,
+			"metadata":  param.Metadata,
+		}
+	}
+	d.Set("param", params)
+
+	log.Printf("[DEBUG] Finish FastEdge template data source read (id=%d)\n", id)
+	return nil
+}
+
+// findFastEdgeTemplateByName returns the id of the template with the given name, or 0 if not found
+func findFastEdgeTemplateByName(ctx context.Context, client *sdk.ClientWithResponses, name string) (int64, error) {
+	limit := fastEdgeListPageSize
+	for offset := 0; ; offset += limit {
+		rsp, err := client.ListTemplatesWithResponse(ctx, &sdk.ListTemplatesParams{Limit: &limit, Offset: &offset})
+		if err != nil {
+			return 0, fmt.Errorf("calling ListTemplates API: %w", err)
+		}
+		if !statusOK(rsp.StatusCode()) {
+			return 0, fmt.Errorf("calling ListTemplates API: %s", extractErrorMessage(rsp.Body))
+		}
+		for _, template := range rsp.JSON200.Templates {
+			if template.Name == name {
+				return template.Id, nil
+			}
+		}
+		if len(rsp.JSON200.Templates) < limit {
+			return 0, nil
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package gcore
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+)
+
+func fastedgeMockConfig(mock sdk.ClientInterface) *Config {
+	return &Config{FastEdgeClient: &sdk.ClientWithResponses{ClientInterface: mock}}
+}
+
+func TestFastEdgeAppDataSource(t *testing.T) {
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"GetAppIdByName": {
+				params: []mockParams{
+					{
+						expectPayload: "test-app",
+						retStatus:     http.StatusOK,
+						retBody:       `42`,
+					},
+				},
+			},
+			"GetApp": {
+				params: []mockParams{
+					{
+						expectId:  42,
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, "name": "test-app", "url": "https://test-app.fastedge.app", ` + baseAppJson + `}`,
+					},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastEdgeApp().Schema, map[string]any{"name": "test-app"})
+	if diags := dataSourceFastEdgeAppRead(context.Background(), d, fastedgeMockConfig(mock)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	compare(t, d.Id(), "42", "id", 0)
+	compare(t, d.Get("url"), "https://test-app.fastedge.app", "url", 0)
+	compare(t, d.Get("status"), "enabled", "status", 0)
+	compare(t, d.Get("binary"), 314, "binary", 0)
+	compare(t, d.Get("env_keys"), []any{"key"}, "env_keys", 0)
+	compare(t, d.Get("secret_keys"), []any{"foo"}, "secret_keys", 0)
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestFastEdgeTemplateDataSource(t *testing.T) {
+	limit := fastEdgeListPageSize
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"ListTemplates": {
+				params: []mockParams{
+					{
+						expectPayload: sdk.ListTemplatesParams{Limit: &limit, Offset: ptr(0)},
+						retStatus:     http.StatusOK,
+						retBody:       `{"count": 2, "templates": [{"id": 1, "name": "other", "api_type": "wasi-http", "owned": true}, {"id": 2, "name": "test-template", "api_type": "wasi-http", "owned": true}]}`,
+					},
+				},
+			},
+			"GetTemplate": {
+				params: []mockParams{
+					{
+						expectId:  2,
+						retStatus: http.StatusOK,
+						retBody: `{"name": "test-template", "binary_id": 314, "owned": true, "params": [
+							{"name": "foo", "data_type": "string", "mandatory": true, "descr": "Parameter foo"}
+						]}`,
+					},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastEdgeTemplate().Schema, map[string]any{"name": "test-template"})
+	if diags := dataSourceFastEdgeTemplateRead(context.Background(), d, fastedgeMockConfig(mock)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	compare(t, d.Id(), "2", "id", 0)
+	compare(t, d.Get("binary"), 314, "binary", 0)
+	compare(t, d.Get("param.0.name"), "foo", "param name", 0)
+	compare(t, d.Get("param.0.type"), "string", "param type", 0)
+	compare(t, d.Get("param.0.mandatory"), true, "param mandatory", 0)
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestFastEdgeSecretDataSource(t *testing.T) {
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"ListSecrets": {
+				params: []mockParams{
+					{
+						expectPayload: sdk.ListSecretsParams{SecretName: ptr("test-secret")},
+						retStatus:     http.StatusOK,
+						retBody:       `{"secrets": [{"id": 7, "name": "test-secret"}]}`,
+					},
+				},
+			},
+			"GetSecret": {
+				params: []mockParams{
+					{
+						expectId:  7,
+						retStatus: http.StatusOK,
+						retBody:   `{"name": "test-secret", "comment": "test", "app_count": 2, "secret_slots": [{"slot": 0, "checksum": "abc"}]}`,
+					},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastEdgeSecret().Schema, map[string]any{"name": "test-secret"})
+	if diags := dataSourceFastEdgeSecretRead(context.Background(), d, fastedgeMockConfig(mock)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	compare(t, d.Id(), "7", "id", 0)
+	compare(t, d.Get("app_count"), 2, "app_count", 0)
+	compare(t, d.Get("slot"), []any{map[string]any{"id": 0, "checksum": "abc"}}, "slot", 0)
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestFastEdgeAppsDataSource(t *testing.T) {
+	limit := fastEdgeListPageSize
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"ListApps": {
+				params: []mockParams{
+					{
+						expectPayload: sdk.ListAppsParams{Limit: &limit, Offset: ptr(0), Status: ptr(1), Template: ptr[int64](3)},
+						retStatus:     http.StatusOK,
+						retBody: `{"count": 1, "apps": [
+							{"id": 42, "name": "test-app", "status": 1, "binary": 314, "template": 3, "api_type": "wasi-http", "plan_id": 1}
+						]}`,
+					},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastEdgeApps().Schema, map[string]any{"status": "enabled", "template": 3})
+	if diags := dataSourceFastEdgeAppsRead(context.Background(), d, fastedgeMockConfig(mock)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	want := []any{map[string]any{
+		"id":       42,
+		"name":     "test-app",
+		"status":   "enabled",
+		"binary":   314,
+		"template": 3,
+		"api_type": "wasi-http",
+		"comment":  "",
+	}}
+	if diff := cmp.Diff(want, d.Get("apps")); diff != "" {
+		t.Errorf("unexpected apps (-want +got):\n%s", diff)
+	}
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestFastEdgeBinaryDataSource(t *testing.T) {
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"ListBinaries": {
+				params: []mockParams{
+					{
+						retStatus: http.StatusOK,
+						retBody:   `{"binaries": [{"id": 41, "checksum": "aaa", "api_type": "wasi-http", "status": 1}, {"id": 42, "checksum": "bbb", "api_type": "wasi-http", "status": 1}]}`,
+					},
+				},
+			},
+			"GetBinary": {
+				params: []mockParams{
+					{
+						expectId:  42,
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, "checksum": "bbb", "api_type": "wasi-http", "status": 1, "source": 1}`,
+					},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastEdgeBinary().Schema, map[string]any{"checksum": "bbb"})
+	if diags := dataSourceFastEdgeBinaryRead(context.Background(), d, fastedgeMockConfig(mock)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	compare(t, d.Id(), "42", "id", 0)
+	compare(t, d.Get("binary_id"), 42, "binary_id", 0)
+	compare(t, d.Get("api_type"), "wasi-http", "api_type", 0)
+
+	mock.ExpectationsWereMet(t)
+}
+
+// proxy-wasm module replying with the "token" secret, or passing the request to the origin
+// and adding the "x-app: checked" header if the secret is not set
+const testFastEdgeProxyWasm = "AGFzbQEAAAABLwZgBH9/f38Bf2AIf39/f39/f38Bf2ABfwF/YAJ/fwBgA39/fwF/YAV/f39/fwF/AlkDA2VudhBwcm94eV9nZXRfc2VjcmV0AAADZW52GXByb3h5X3NlbmRfbG9jYWxfcmVzcG9uc2UAAQNlbnYacHJveHlfYWRkX2hlYWRlcl9tYXBfdmFsdWUABQMFBAIDBAQFAwEAAQYHAX8BQYAICwd2BQZtZW1vcnkCABhwcm94eV9vbl9tZW1vcnlfYWxsb2NhdGUAAxdwcm94eV9vbl9jb250ZXh0X2NyZWF0ZQAEGHByb3h5X29uX3JlcXVlc3RfaGVhZGVycwAFGXByb3h5X29uX3Jlc3BvbnNlX2hlYWRlcnMABgpSBAsAIwAjACAAaiQACwIACy8AQRBBBUEAQQQQAEUEQEHIAUEAQQBBACgCAEEEKAIAQQBBAEF/EAEaQQEPC0EACxEAQQJBKEEFQTBBBxACGkEACwshAwBBEAsFdG9rZW4AQSgLBXgtYXBwAEEwCwdjaGVja2Vk"
+
+func TestFastEdgeAppCheckDataSource(t *testing.T) {
+	raw := map[string]any{
+		"content_base64": testFastEdgeProxyWasm,
+		"secrets":        map[string]any{"token": "s3cr3t"},
+		"rsp_headers":    map[string]any{"x-frame-options": "DENY"},
+		"request": []any{
+			map[string]any{
+				"url":              "https://example.com/",
+				"expected_status":  200,
+				"expected_body":    "^s3cr3t$",
+				"expected_headers": map[string]any{"x-frame-options": "DENY"},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, dataSourceFastEdgeAppCheck().Schema, raw)
+	if diags := dataSourceFastEdgeAppCheckRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	compare(t, d.Get("request.0.status"), 200, "status", 0)
+	compare(t, d.Get("request.0.response_body"), "s3cr3t", "response_body", 0)
+
+	// the same binary from a file
+	payload, err := base64.StdEncoding.DecodeString(testFastEdgeProxyWasm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "app.wasm")
+	if err := os.WriteFile(filename, payload, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	delete(raw, "content_base64")
+	raw["filename"] = filename
+	d = schema.TestResourceDataRaw(t, dataSourceFastEdgeAppCheck().Schema, raw)
+	if diags := dataSourceFastEdgeAppCheckRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	compare(t, d.Get("request.0.response_body"), "s3cr3t", "response_body", 0)
+
+	// without the secret the request goes to the origin
+	raw["secrets"] = map[string]any{}
+	raw["request"] = []any{
+		map[string]any{
+			"origin_status":    http.StatusNotFound,
+			"origin_body":      "not found",
+			"expected_status":  200,
+			"expected_body":    "s3cr3t",
+			"expected_headers": map[string]any{"x-app": "checked", "x-missing": "1"},
+		},
+	}
+	d = schema.TestResourceDataRaw(t, dataSourceFastEdgeAppCheck().Schema, raw)
+	diags := dataSourceFastEdgeAppCheckRead(context.Background(), d, nil)
+	details := []string{}
+	for _, diag := range diags {
+		details = append(details, diag.Detail)
+	}
+	compare(t, details, []string{
+		"expected status 200, got 404",
+		`expected body to match "s3cr3t", got "not found"`,
+		"expected header x-missing: 1, header is missing",
+	}, "diagnostics", 0)
+}
@@ -0,0 +1,159 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLifecyclePolicySnapshots() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLifecyclePolicySnapshotsRead,
+		Description: "Get snapshots taken by a lifecycle policy, including copies in other regions, with their expiry time.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ExactlyOneOf:     []string{"project_id", "project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+				Description:      "Project ID, only one of project_id or project_name should be set",
+			},
+			"region_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ExactlyOneOf:     []string{"region_id", "region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+				Description:      "Region ID, only one of region_id or region_name should be set",
+			},
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"project_id", "project_name"},
+				Description:  "Project name, only one of project_id or project_name should be set",
+			},
+			"region_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"region_id", "region_name"},
+				Description:  "Region name, only one of region_id or region_name should be set",
+			},
+			"lifecyclepolicy_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the lifecycle policy.",
+			},
+			"snapshots": {
+				Type:        schema.TypeList,
+				Description: "Snapshots taken by the policy.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "Snapshot ID.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Snapshot name.",
+							Computed:    true,
+						},
+						"volume_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the snapshotted volume.",
+							Computed:    true,
+						},
+						"instance_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the backed up instance, only for 'instance_backup' policies.",
+							Computed:    true,
+						},
+						"schedule_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the schedule which took the snapshot.",
+							Computed:    true,
+						},
+						"region_id": {
+							Type:        schema.TypeInt,
+							Description: "Region of the snapshot, differs from the policy region for cross-region copies.",
+							Computed:    true,
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Description: "Snapshot size in GB.",
+							Computed:    true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Description: "Snapshot status.",
+							Computed:    true,
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Description: "Snapshot creation time.",
+							Computed:    true,
+						},
+						"expires_at": {
+							Type:        schema.TypeString,
+							Description: "Time the snapshot is deleted according to the retention time of the schedule, empty if it is kept until max_quantity is reached.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLifecyclePolicySnapshotsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start lifecycle policy snapshots data source reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	projectID, regionID, err := getProjectAndRegionID(provider, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyID := d.Get("lifecyclepolicy_id").(int)
+
+	client, err := CreateClient(provider, d, lifecyclePolicyPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshots, err := listLifecyclePolicySnapshots(client, policyID)
+	if err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if errors.As(err, &errDefault404) {
+			return diag.Errorf("lifecycle policy %d not found in project %d and region %d", policyID, projectID, regionID)
+		}
+		return diag.FromErr(err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		result = append(result, map[string]interface{}{
+			"id":          snapshot.ID,
+			"name":        snapshot.Name,
+			"volume_id":   snapshot.VolumeID,
+			"instance_id": snapshot.InstanceID,
+			"schedule_id": snapshot.ScheduleID,
+			"region_id":   snapshot.RegionID,
+			"size":        snapshot.Size,
+			"status":      snapshot.Status,
+			"created_at":  snapshot.CreatedAt,
+			"expires_at":  snapshot.ExpiresAt,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d:%d", projectID, regionID, policyID))
+	d.Set("snapshots", result)
+
+	log.Printf("[DEBUG] Read snapshots of lifecycle policy %d", policyID)
+	return nil
+}
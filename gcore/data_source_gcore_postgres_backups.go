@@ -0,0 +1,153 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePostgresBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePostgresBackupsRead,
+		Description: "Get backups of a PostgreSQL cluster in Gcore Cloud, e.g. to restore a cluster with `restore_from`.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ExactlyOneOf:     []string{"project_id", "project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+				Description:      "Project ID, only one of project_id or project_name should be set",
+			},
+			"region_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ExactlyOneOf:     []string{"region_id", "region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+				Description:      "Region ID, only one of region_id or region_name should be set",
+			},
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"project_id", "project_name"},
+				Description:  "Project name, only one of project_id or project_name should be set",
+			},
+			"region_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"region_id", "region_name"},
+				Description:  "Region name, only one of region_id or region_name should be set",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the PostgreSQL cluster.",
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Description: "Available backups, from the oldest one.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "Backup ID.",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Backup type, `scheduled` or `manual`.",
+							Computed:    true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Description: "Backup status.",
+							Computed:    true,
+						},
+						"size_bytes": {
+							Type:        schema.TypeInt,
+							Description: "Backup size in bytes.",
+							Computed:    true,
+						},
+						"storage_location": {
+							Type:        schema.TypeString,
+							Description: "Storage location of the backup.",
+							Computed:    true,
+						},
+						"started_at": {
+							Type:        schema.TypeString,
+							Description: "Backup start time.",
+							Computed:    true,
+						},
+						"finished_at": {
+							Type:        schema.TypeString,
+							Description: "Backup finish time.",
+							Computed:    true,
+						},
+						"recovery_window_start": {
+							Type:        schema.TypeString,
+							Description: "Earliest moment for point-in-time restore based on the backup.",
+							Computed:    true,
+						},
+						"recovery_window_end": {
+							Type:        schema.TypeString,
+							Description: "Latest moment for point-in-time restore based on the backup.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePostgresBackupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start PostgreSQL backups data source reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	projectID, regionID, err := getProjectAndRegionID(provider, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	clusterName := d.Get("cluster_name").(string)
+
+	client, err := CreateClient(provider, d, postgresClustersPoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backups, err := listPostgresBackups(client, clusterName)
+	if err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if errors.As(err, &errDefault404) {
+			return diag.Errorf("PostgreSQL cluster with name '%s' not found in project %d and region %d", clusterName, projectID, regionID)
+		}
+		return diag.FromErr(err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(backups))
+	for _, backup := range backups {
+		result = append(result, map[string]interface{}{
+			"id":                    backup.ID,
+			"type":                  backup.Type,
+			"status":                backup.Status,
+			"size_bytes":            backup.SizeBytes,
+			"storage_location":      backup.StorageLocation,
+			"started_at":            backup.StartedAt,
+			"finished_at":           backup.FinishedAt,
+			"recovery_window_start": backup.RecoveryWindowStart,
+			"recovery_window_end":   backup.RecoveryWindowEnd,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d:%s", projectID, regionID, clusterName))
+	d.Set("backups", result)
+
+	log.Printf("[DEBUG] Read PostgreSQL backups of cluster %s", clusterName)
+	return nil
+}
@@ -0,0 +1,191 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePostgresConfiguration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePostgresConfigurationRead,
+		Description: "Get PostgreSQL versions, flavors and parameters available for clusters in a Gcore Cloud region.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ExactlyOneOf:     []string{"project_id", "project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+				Description:      "Project ID, only one of project_id or project_name should be set",
+			},
+			"region_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ExactlyOneOf:     []string{"region_id", "region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+				Description:      "Region ID, only one of region_id or region_name should be set",
+			},
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"project_id", "project_name"},
+				Description:  "Project name, only one of project_id or project_name should be set",
+			},
+			"region_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"region_id", "region_name"},
+				Description:  "Region name, only one of region_id or region_name should be set",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PostgreSQL version to list parameters for, parameters of all versions if not set.",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Available PostgreSQL versions.",
+			},
+			"flavors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Available flavors of cluster instances.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cpu": {
+							Type:        schema.TypeInt,
+							Description: "Number of CPU cores.",
+							Computed:    true,
+						},
+						"memory": {
+							Type:        schema.TypeInt,
+							Description: "Amount of RAM in GiB.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"parameters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Parameters allowed in `parameters` of `gcore_postgres_cluster`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Parameter name.",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Value type, one of `bool`, `integer`, `real`, `enum` and `string`.",
+							Computed:    true,
+						},
+						"unit": {
+							Type:        schema.TypeString,
+							Description: "Base unit of the value, e.g. `8kB` or `ms`. Values may use other units of the same kind, e.g. `1GB`.",
+							Computed:    true,
+						},
+						"min": {
+							Type:        schema.TypeString,
+							Description: "Minimal value in the base unit, empty if not limited.",
+							Computed:    true,
+						},
+						"max": {
+							Type:        schema.TypeString,
+							Description: "Maximal value in the base unit, empty if not limited.",
+							Computed:    true,
+						},
+						"allowed_values": {
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Allowed values of `enum` parameters.",
+							Computed:    true,
+						},
+						"restart_required": {
+							Type:        schema.TypeBool,
+							Description: "Whether a change takes effect after a cluster restart.",
+							Computed:    true,
+						},
+						"versions": {
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "PostgreSQL versions supporting the parameter.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePostgresConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start PostgreSQL configuration data source reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	projectID, regionID, err := getProjectAndRegionID(provider, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := CreateClient(provider, d, postgresConfigurationPoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	configuration, err := getPostgresConfiguration(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	version := d.Get("version").(string)
+	if version != "" && !slices.Contains(configuration.Versions, version) {
+		return diag.Errorf("PostgreSQL version %s is not available in project %d and region %d", version, projectID, regionID)
+	}
+
+	flavors := make([]map[string]interface{}, 0, len(configuration.Flavors))
+	for _, flavor := range configuration.Flavors {
+		flavors = append(flavors, map[string]interface{}{
+			"cpu":    flavor.CPU,
+			"memory": flavor.MemoryGiB,
+		})
+	}
+
+	parameters := make([]map[string]interface{}, 0, len(configuration.Parameters))
+	for _, p := range configuration.Parameters {
+		if version != "" && len(p.Versions) > 0 && !slices.Contains(p.Versions, version) {
+			continue
+		}
+		parameter := map[string]interface{}{
+			"name":             p.Name,
+			"type":             p.Type,
+			"unit":             p.Unit,
+			"allowed_values":   p.AllowedValues,
+			"restart_required": p.RestartRequired,
+			"versions":         p.Versions,
+		}
+		if p.Min != nil {
+			parameter["min"] = postgresParameterBound(p.Min)
+		}
+		if p.Max != nil {
+			parameter["max"] = postgresParameterBound(p.Max)
+		}
+		parameters = append(parameters, parameter)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d:%s", projectID, regionID, version))
+	d.Set("versions", configuration.Versions)
+	d.Set("flavors", flavors)
+	d.Set("parameters", parameters)
+
+	log.Printf("[DEBUG] Read PostgreSQL configuration of region %d", regionID)
+	return nil
+}
@@ -0,0 +1,127 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/dbaas/postgres/v1/clusters"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePostgresUser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePostgresUserRead,
+		Description: "Get credentials and the connection URI of a PostgreSQL cluster user in Gcore Cloud. " +
+			"Reading the data source reveals the user password.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ExactlyOneOf:     []string{"project_id", "project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+				Description:      "Project ID, only one of project_id or project_name should be set",
+			},
+			"region_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ExactlyOneOf:     []string{"region_id", "region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+				Description:      "Region ID, only one of region_id or region_name should be set",
+			},
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"project_id", "project_name"},
+				Description:  "Project name, only one of project_id or project_name should be set",
+			},
+			"region_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"region_id", "region_name"},
+				Description:  "Region name, only one of region_id or region_name should be set",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the PostgreSQL cluster.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "User name.",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Database of `connection_uri`.",
+			},
+			"role_attributes": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of role attributes.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "User password.",
+			},
+			"connection_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Connection URI of the primary instance with the user credentials.",
+			},
+		},
+	}
+}
+
+func dataSourcePostgresUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start PostgreSQL user data source reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	projectID, regionID, err := getProjectAndRegionID(provider, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	clusterName := d.Get("cluster_name").(string)
+	userName := d.Get("name").(string)
+
+	client, err := CreateClient(provider, d, postgresClustersPoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cluster, err := clusters.Get(client, clusterName).Extract()
+	if err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if errors.As(err, &errDefault404) {
+			return diag.Errorf("PostgreSQL cluster with name '%s' not found in project %d and region %d", clusterName, projectID, regionID)
+		}
+		return diag.FromErr(err)
+	}
+	user := findPostgresUser(cluster, userName)
+	if user == nil {
+		return diag.Errorf("user '%s' not found in PostgreSQL cluster '%s'", userName, clusterName)
+	}
+
+	creds, err := getPostgresUserCredentials(client, clusterName, userName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cannot get credentials of PostgreSQL user %s: %w", userName, err))
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d:%s:%s", projectID, regionID, clusterName, userName))
+	d.Set("role_attributes", clusters.RoleAttributeSliceToStrings(user.RoleAttributes))
+	d.Set("password", creds.Password)
+	d.Set("connection_uri", postgresConnectionURI(cluster.Network.ConnectionString, cluster.Network.Host,
+		userName, creds.Password, d.Get("database").(string)))
+
+	log.Printf("[DEBUG] Read PostgreSQL user %s", d.Id())
+	return nil
+}
@@ -0,0 +1,91 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const StorageLocationsSchemaLocations = "locations"
+
+func dataSourceStorageLocations() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			StoragesSchemaType: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"s3", "sftp"}, false),
+				Description:  "A storage type of locations to list, s3 or sftp, all types if empty.",
+			},
+			StorageLocationsSchemaLocations: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Storage locations.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A name of location, used as location of storages.",
+						},
+						StoragesSchemaType: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A storage type of location, s3 or sftp.",
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "An address of location.",
+						},
+						"available": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether new storages can be created in the location.",
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceStorageLocationsRead,
+		Description: "Represent list of storage locations.",
+	}
+}
+
+func dataSourceStorageLocationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	storageType := d.Get(StoragesSchemaType).(string)
+	log.Printf("[DEBUG] Start Storage Locations reading (type=%s)\n", storageType)
+	defer log.Println("[DEBUG] Finish Storage Locations reading")
+
+	config := m.(*Config)
+	if config.StorageAPI == nil {
+		return diag.Errorf("storage api is not configured")
+	}
+	result, err := config.StorageAPI.locations(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	locations := make([]interface{}, 0, len(result))
+	for _, l := range result {
+		if l == nil || (storageType != "" && l.Type != storageType) {
+			continue
+		}
+		locations = append(locations, map[string]interface{}{
+			"name":             l.Name,
+			StoragesSchemaType: l.Type,
+			"address":          l.Address,
+			"available":        l.AllowForNewStorage != "deny",
+		})
+	}
+
+	d.SetId(fmt.Sprintf("locations:%s", storageType))
+	if err := d.Set(StorageLocationsSchemaLocations, locations); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
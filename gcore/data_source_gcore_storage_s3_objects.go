@@ -0,0 +1,126 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	StorageS3ObjectsSchemaPrefix  = "prefix"
+	StorageS3ObjectsSchemaKeys    = "keys"
+	StorageS3ObjectsSchemaObjects = "objects"
+)
+
+func dataSourceStorageS3Objects() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			StorageS3BucketSchemaStorageID: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "An id of existing storage resource.",
+			},
+			StorageS3ObjectSchemaBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "A name of existing storage bucket.",
+			},
+			StorageS3ObjectsSchemaPrefix: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Key prefix of the objects to list, all objects if empty.",
+			},
+			StorageS3BucketSchemaAccessKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "S3 access key of the storage, e.g. generated_access_key of gcore_storage_s3.",
+			},
+			StorageS3BucketSchemaSecretKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "S3 secret key of the storage, e.g. generated_secret_key of gcore_storage_s3.",
+			},
+			StorageS3ObjectsSchemaKeys: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Object keys, in lexicographical order.",
+			},
+			StorageS3ObjectsSchemaObjects: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Objects, in lexicographical key order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						StorageS3ObjectSchemaKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Object key.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Object size in bytes.",
+						},
+						StorageS3ObjectSchemaETag: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ETag of the object.",
+						},
+						"last_modified": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Last modification time, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceStorageS3ObjectsRead,
+		Description: "Represent list of s3 storage objects, read via the S3 API with the storage keys.",
+	}
+}
+
+func dataSourceStorageS3ObjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	storageId := d.Get(StorageS3BucketSchemaStorageID).(int)
+	bucket := d.Get(StorageS3ObjectSchemaBucket).(string)
+	prefix := d.Get(StorageS3ObjectsSchemaPrefix).(string)
+	log.Printf("[DEBUG] Start S3 Storage Objects reading (id=%d, bucket=%s, prefix=%s)\n", storageId, bucket, prefix)
+	defer log.Println("[DEBUG] Finish S3 Storage Objects reading")
+
+	s3, err := storageS3ObjectClient(ctx, d, m.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	result, err := s3.listObjects(ctx, bucket, prefix)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("storage objects list: %w", err))
+	}
+
+	keys := make([]string, 0, len(result))
+	objects := make([]interface{}, 0, len(result))
+	for _, object := range result {
+		keys = append(keys, object.Key)
+		objects = append(objects, map[string]interface{}{
+			StorageS3ObjectSchemaKey:  object.Key,
+			"size":                    int(object.Size),
+			StorageS3ObjectSchemaETag: strings.Trim(object.ETag, `"`),
+			"last_modified":           object.LastModified,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s:%s", storageId, bucket, prefix))
+	if err := d.Set(StorageS3ObjectsSchemaKeys, keys); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(StorageS3ObjectsSchemaObjects, objects); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/client/statistics"
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	StorageUsageSchemaFrom      = "from"
+	StorageUsageSchemaTo        = "to"
+	StorageUsageSchemaLocations = "locations"
+	StorageUsageSchemaStorages  = "storages"
+	StorageUsageSchemaUsage     = "usage"
+)
+
+func dataSourceStorageUsage() *schema.Resource {
+	validateDate := func(i interface{}, k string) ([]string, []error) {
+		if _, err := time.Parse(time.DateOnly, i.(string)); err != nil {
+			return nil, []error{fmt.Errorf("%s must be a date in YYYY-MM-DD format, got %q", k, i)}
+		}
+		return nil, nil
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			StorageUsageSchemaFrom: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDate,
+				Description:  "Start date of the time range, in YYYY-MM-DD format.",
+			},
+			StorageUsageSchemaTo: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDate,
+				Description:  "End date of the time range, in YYYY-MM-DD format.",
+			},
+			StorageUsageSchemaLocations: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Locations to report, all locations if empty.",
+			},
+			StorageUsageSchemaStorages: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Full names of storages to report, with the client id prefix, e.g. `123-example`. All storages if empty.",
+			},
+			StorageUsageSchemaUsage: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Usage per storage, ordered by location and name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A full name of storage.",
+						},
+						StorageSchemaLocation: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A location of storage.",
+						},
+						"size_max": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Maximum size in bytes over the time range.",
+						},
+						"size_mean": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Mean size in bytes over the time range.",
+						},
+						"objects": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Maximum number of objects (files) over the time range.",
+						},
+						"traffic": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Total traffic in bytes.",
+						},
+						"traffic_in": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Incoming traffic in bytes.",
+						},
+						"traffic_out": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Outgoing traffic in bytes, to CDN edges and direct.",
+						},
+						"requests": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Total number of requests.",
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceStorageUsageRead,
+		Description: "Represent storage usage statistics: size, object count and traffic per storage over a time range.",
+	}
+}
+
+func dataSourceStorageUsageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	body := statistics.StorageUsageSeriesHTTPPostBody{
+		From:      d.Get(StorageUsageSchemaFrom).(string),
+		To:        d.Get(StorageUsageSchemaTo).(string),
+		Locations: expandStringList(d.Get(StorageUsageSchemaLocations).([]interface{})),
+		Storages:  expandStringList(d.Get(StorageUsageSchemaStorages).([]interface{})),
+	}
+	log.Printf("[DEBUG] Start Storage Usage reading (from=%s, to=%s)\n", body.From, body.To)
+	defer log.Println("[DEBUG] Finish Storage Usage reading")
+
+	config := m.(*Config)
+	if config.StorageAPI == nil {
+		return diag.Errorf("storage api is not configured")
+	}
+	result, err := config.StorageAPI.usage(ctx, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s:%s", body.From, body.To, strings.Join(body.Locations, ","), strings.Join(body.Storages, ",")))
+	if err := d.Set(StorageUsageSchemaUsage, flattenStorageUsage(result)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func flattenStorageUsage(result *models.StorageUsageSeriesServiceRes) []interface{} {
+	usage := make([]interface{}, 0)
+	for _, client := range result.Clients {
+		for locationName, location := range client.Locations {
+			if location.Name != "" {
+				locationName = location.Name
+			}
+			for storageName, st := range location.Storages {
+				if st.Name != "" {
+					storageName = st.Name
+				}
+				usage = append(usage, map[string]interface{}{
+					"name":                storageName,
+					StorageSchemaLocation: locationName,
+					"size_max":            int(st.SizeSumMax),
+					"size_mean":           int(st.SizeSumMean),
+					"objects":             int(st.FileQuantitySumMax),
+					"traffic":             int(st.TrafficSum),
+					"traffic_in":          int(st.TrafficInSum),
+					"traffic_out":         int(st.TrafficOutEdgesSum + st.TrafficOutWoEdgesSum),
+					"requests":            int(st.RequestsSum),
+				})
+			}
+		}
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		a, b := usage[i].(map[string]interface{}), usage[j].(map[string]interface{})
+		if a[StorageSchemaLocation] != b[StorageSchemaLocation] {
+			return a[StorageSchemaLocation].(string) < b[StorageSchemaLocation].(string)
+		}
+		return a["name"].(string) < b["name"].(string)
+	})
+	return usage
+}
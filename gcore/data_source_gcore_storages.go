@@ -0,0 +1,146 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/client/storage"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	StoragesSchemaType     = "type"
+	StoragesSchemaStorages = "storages"
+
+	// storagesPageSize is the number of storages requested per page
+	storagesPageSize = 100
+)
+
+func dataSourceStorages() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			StorageSchemaLocation: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A location of storages to list, all locations if empty.",
+			},
+			StoragesSchemaType: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"s3", "sftp"}, false),
+				Description:  "A type of storages to list, s3 or sftp, all types if empty.",
+			},
+			StoragesSchemaStorages: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Storages, deleted storages are not included.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						StorageSchemaId: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "An id of storage.",
+						},
+						StorageSchemaName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A name of storage.",
+						},
+						StoragesSchemaType: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A type of storage, s3 or sftp.",
+						},
+						StorageSchemaLocation: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A location of storage.",
+						},
+						"provisioning_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A provisioning status of storage.",
+						},
+						StorageSFTPSchemaServerAlias: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "An alias of storage.",
+						},
+						StorageSFTPSchemaKeyId: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "An ssh keys IDs linked with sftp storage.",
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceStoragesRead,
+		Description: "Represent list of storages with their linked ssh keys. https://storage.gcore.com/storage/list",
+	}
+}
+
+func dataSourceStoragesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	location := d.Get(StorageSchemaLocation).(string)
+	storageType := d.Get(StoragesSchemaType).(string)
+	log.Printf("[DEBUG] Start Storages reading (location=%s, type=%s)\n", location, storageType)
+	defer log.Println("[DEBUG] Finish Storages reading")
+
+	config := m.(*Config)
+	client := config.StorageClient
+
+	storages := make([]interface{}, 0)
+	for offset := uint64(0); ; offset += storagesPageSize {
+		opts := []func(opt *storage.StorageListHTTPV2Params){
+			func(opt *storage.StorageListHTTPV2Params) { opt.Context = ctx },
+			func(opt *storage.StorageListHTTPV2Params) { opt.ShowDeleted = new(bool) },
+			func(opt *storage.StorageListHTTPV2Params) {
+				limit, pageOffset := uint64(storagesPageSize), offset
+				opt.Limit, opt.Offset = &limit, &pageOffset
+			},
+		}
+		if location != "" {
+			opts = append(opts, func(opt *storage.StorageListHTTPV2Params) { opt.Location = &location })
+		}
+		if storageType != "" {
+			opts = append(opts, func(opt *storage.StorageListHTTPV2Params) { opt.Type = &storageType })
+		}
+
+		result, err := client.StoragesList(opts...)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("storages list: %w", err))
+		}
+		for _, st := range result {
+			keys := make([]int, 0)
+			if st.Credentials != nil {
+				for _, k := range st.Credentials.Keys {
+					if k != nil {
+						keys = append(keys, int(k.ID))
+					}
+				}
+			}
+			storages = append(storages, map[string]interface{}{
+				StorageSchemaId:              int(st.ID),
+				StorageSchemaName:            st.Name,
+				StoragesSchemaType:           st.Type,
+				StorageSchemaLocation:        st.Location,
+				"provisioning_status":        st.ProvisioningStatus,
+				StorageSFTPSchemaServerAlias: st.ServerAlias,
+				StorageSFTPSchemaKeyId:       keys,
+			})
+		}
+		if len(result) < storagesPageSize {
+			break
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", location, storageType))
+	if err := d.Set(StoragesSchemaStorages, storages); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStoragesDataSource(t *testing.T) {
+	random := time.Now().Nanosecond()
+	name := fmt.Sprintf("terraformtestlist%d", random)
+	dataSourceName := fmt.Sprintf("data.gcore_storages.%s", name)
+
+	template := fmt.Sprintf(`
+resource "gcore_storage_sftp" "%[1]s" {
+  name = "%[1]s"
+  location = "mia"
+}
+
+data "gcore_storages" "%[1]s" {
+  location = gcore_storage_sftp.%[1]s.location
+  type = "sftp"
+}
+	`, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckVars(t, GCORE_USERNAME_VAR, GCORE_PASSWORD_VAR, GCORE_STORAGE_URL_VAR)
+		},
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: template,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(dataSourceName),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "storages.*", map[string]string{
+						StoragesSchemaType:    "sftp",
+						StorageSchemaLocation: "mia",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,529 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	waap "github.com/G-Core/gcore-waap-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataWaapCustomRuleCEL() *schema.Resource {
+	conditions := copySchemaWithoutConstraints(resourceWaapCustomRule().Schema["conditions"])
+	conditions.Required = false
+	conditions.Optional = true
+	conditions.Computed = true
+	conditions.ExactlyOneOf = []string{"conditions", "source"}
+	conditions.Description = "The conditions of a WAAP Custom Rule. The arguments are the same as in `gcore_waap_custom_rule`. " +
+		"When `source` is specified, contains the conditions parsed from the CEL expression."
+
+	return &schema.Resource{
+		ReadContext: dataWaapCustomRuleCELRead,
+		Description: "Convert the conditions of a WAAP Custom Rule to a CEL expression usable in `gcore_waap_advanced_rule`, " +
+			"or a CEL expression back to Custom Rule conditions. " +
+			"Only conditions combined with `&&` can be converted back, terms that have no Custom Rule equivalent are reported in `unsupported`.",
+		Schema: map[string]*schema.Schema{
+			"conditions": conditions,
+			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"conditions", "source"},
+				Description: "A CEL syntax expression of an Advanced Rule. " +
+					"When `conditions` is specified, contains the CEL expression equivalent to the conditions.",
+			},
+			"unsupported": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Conditions or CEL terms that could not be converted. " +
+					"Request rate conditions have no CEL equivalent and are always reported here.",
+			},
+		},
+	}
+}
+
+func dataWaapCustomRuleCELRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start converting WAAP Custom Rule conditions")
+
+	var source string
+	var conditions []waap.CustomRuleConditionInput
+	var unsupported []string
+
+	if v, ok := d.GetOk("source"); ok {
+		source = v.(string)
+		conditions, unsupported = parseWaapConditionsCEL(source)
+	} else {
+		conditions = sortCustomRuleConditions(getConditionsPayload(d.Get("conditions")))
+		source, unsupported = waapConditionsToCEL(conditions)
+	}
+
+	outputs := make([]waap.CustomRuleConditionOutput, 0, len(conditions))
+	for _, condition := range conditions {
+		outputs = append(outputs, waapConditionInputToOutput(condition))
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(source)))
+	d.Set("source", source)
+	if err := d.Set("conditions", readConditionsFromResponse(outputs)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("unsupported", unsupported)
+
+	log.Println("[DEBUG] Finish converting WAAP Custom Rule conditions")
+	return nil
+}
+
+// waapConditionsToCEL builds an Advanced Rule source from Custom Rule conditions.
+// All conditions must pass for a Custom Rule to trigger, so the terms are joined with &&.
+func waapConditionsToCEL(conditions []waap.CustomRuleConditionInput) (string, []string) {
+	terms := []string{}
+	unsupported := []string{}
+
+	for _, c := range conditions {
+		var term string
+		var negation *bool
+
+		switch {
+		case c.Ip != nil:
+			term = "request.ip == " + celQuote(c.Ip.IpAddress)
+			negation = c.Ip.Negation
+		case c.IpRange != nil:
+			term = fmt.Sprintf("request.ip_in_range(%s, %s)", celQuote(c.IpRange.LowerBound), celQuote(c.IpRange.UpperBound))
+			negation = c.IpRange.Negation
+		case c.Url != nil:
+			term = celMatch("request.path", stringValue(c.Url.MatchType), c.Url.Url)
+			negation = c.Url.Negation
+		case c.UserAgent != nil:
+			term = celMatch("request.headers['User-Agent']", stringValue(c.UserAgent.MatchType), c.UserAgent.UserAgent)
+			negation = c.UserAgent.Negation
+		case c.Header != nil:
+			term = celMatch("request.headers["+celQuote(c.Header.Header)+"]", stringValue(c.Header.MatchType), c.Header.Value)
+			negation = c.Header.Negation
+		case c.HeaderExists != nil:
+			term = celQuote(c.HeaderExists.Header) + " in request.headers"
+			negation = c.HeaderExists.Negation
+		case c.ResponseHeader != nil:
+			term = celMatch("response.headers["+celQuote(c.ResponseHeader.Header)+"]", stringValue(c.ResponseHeader.MatchType), c.ResponseHeader.Value)
+			negation = c.ResponseHeader.Negation
+		case c.ResponseHeaderExists != nil:
+			term = celQuote(c.ResponseHeaderExists.Header) + " in response.headers"
+			negation = c.ResponseHeaderExists.Negation
+		case c.HttpMethod != nil:
+			term = "request.method == " + celQuote(string(c.HttpMethod.HttpMethod))
+			negation = c.HttpMethod.Negation
+		case c.FileExtension != nil:
+			term = "request.file_extension in " + celList(c.FileExtension.FileExtension)
+			negation = c.FileExtension.Negation
+		case c.ContentType != nil:
+			term = "request.content_type in " + celList(c.ContentType.ContentType)
+			negation = c.ContentType.Negation
+		case c.Country != nil:
+			term = "whois.country in " + celList(c.Country.CountryCode)
+			negation = c.Country.Negation
+		case c.Organization != nil:
+			term = "whois.org == " + celQuote(c.Organization.Organization)
+			negation = c.Organization.Negation
+		case c.OwnerTypes != nil && c.OwnerTypes.OwnerTypes != nil:
+			ownerTypes := make([]string, 0, len(*c.OwnerTypes.OwnerTypes))
+			for _, ownerType := range *c.OwnerTypes.OwnerTypes {
+				ownerTypes = append(ownerTypes, string(ownerType))
+			}
+			term = "whois.owner_type in " + celList(ownerTypes)
+			negation = c.OwnerTypes.Negation
+		case c.Tags != nil:
+			term = celTagsExists("tags", c.Tags.Tags)
+			negation = c.Tags.Negation
+		case c.UserDefinedTags != nil:
+			term = celTagsExists("user_defined_tags", c.UserDefinedTags.Tags)
+			negation = c.UserDefinedTags.Negation
+		case c.SessionRequestCount != nil:
+			term = fmt.Sprintf("session.request_count >= %d", c.SessionRequestCount.RequestCount)
+			negation = c.SessionRequestCount.Negation
+		case c.RequestRate != nil:
+			unsupported = append(unsupported, fmt.Sprintf("request_rate: %d requests per %d seconds on '%s'",
+				c.RequestRate.Requests, c.RequestRate.Time, c.RequestRate.PathPattern))
+			continue
+		default:
+			continue
+		}
+
+		if negation != nil && *negation {
+			term = "!(" + term + ")"
+		}
+		terms = append(terms, term)
+	}
+
+	return strings.Join(terms, " && "), unsupported
+}
+
+func celMatch(field, matchType, value string) string {
+	switch matchType {
+	case "Exact":
+		return field + " == " + celQuote(value)
+	case "Regex":
+		return field + ".matches(" + celQuote(value) + ")"
+	default:
+		return field + ".contains(" + celQuote(value) + ")"
+	}
+}
+
+func celTagsExists(object string, tags []string) string {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+
+	terms := make([]string, 0, len(sorted))
+	for _, tag := range sorted {
+		terms = append(terms, object+".exists("+celQuote(tag)+")")
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, " || ") + ")"
+}
+
+func celList(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+
+	quoted := make([]string, 0, len(sorted))
+	for _, v := range sorted {
+		quoted = append(quoted, celQuote(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func celQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
+
+func celUnquote(s string) string {
+	s = s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func stringValue[T ~string](v *T) string {
+	if v == nil {
+		return ""
+	}
+	return string(*v)
+}
+
+const (
+	celStringPattern = `('(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*")`
+	celListPattern   = `\[\s*((?:'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*")(?:\s*,\s*(?:'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"))*)\s*\]`
+)
+
+var (
+	celCompareRe      = regexp.MustCompile(`^(request\.ip|request\.method|request\.path|whois\.org|request\.headers\[\s*` + celStringPattern + `\s*\]|response\.headers\[\s*` + celStringPattern + `\s*\])\s*(==|!=)\s*` + celStringPattern + `$`)
+	celCallRe         = regexp.MustCompile(`^(request\.path|request\.headers\[\s*` + celStringPattern + `\s*\]|response\.headers\[\s*` + celStringPattern + `\s*\])\.(contains|matches)\(\s*` + celStringPattern + `\s*\)$`)
+	celIPRangeRe      = regexp.MustCompile(`^request\.ip_in_range\(\s*` + celStringPattern + `\s*,\s*` + celStringPattern + `\s*\)$`)
+	celHeaderExistsRe = regexp.MustCompile(`^` + celStringPattern + `\s+in\s+(request|response)\.headers$`)
+	celInListRe       = regexp.MustCompile(`^(request\.file_extension|request\.content_type|whois\.country|whois\.owner_type)\s+in\s+` + celListPattern + `$`)
+	celRequestCountRe = regexp.MustCompile(`^session\.request_count\s*>=\s*(\d+)$`)
+	celTagsExistsRe   = regexp.MustCompile(`^(tags|user_defined_tags)\.exists\(\s*` + celStringPattern + `\s*\)$`)
+	celListItemRe     = regexp.MustCompile(celStringPattern)
+	celHeaderKeyRe    = regexp.MustCompile(`^(request|response)\.headers\[\s*` + celStringPattern + `\s*\]$`)
+	celCompareFields  = map[string]string{
+		"request.ip":     "ip",
+		"request.method": "http_method",
+		"request.path":   "url",
+		"whois.org":      "organization",
+	}
+)
+
+// parseWaapConditionsCEL converts an Advanced Rule source back to Custom Rule conditions.
+// Only the subset of CEL produced by waapConditionsToCEL is understood, the rest of the terms are returned as unsupported.
+func parseWaapConditionsCEL(source string) ([]waap.CustomRuleConditionInput, []string) {
+	conditions := []waap.CustomRuleConditionInput{}
+	unsupported := []string{}
+
+	for _, term := range splitCELTopLevel(source, "&&") {
+		negation := false
+		for {
+			if inner, ok := unwrapCELParens(term); ok {
+				term = inner
+				continue
+			}
+			if strings.HasPrefix(term, "!") {
+				if inner, ok := unwrapCELParens(strings.TrimSpace(term[1:])); ok {
+					negation = !negation
+					term = inner
+					continue
+				}
+			}
+			break
+		}
+
+		condition, ok := parseWaapConditionCELTerm(term, negation)
+		if !ok {
+			if negation {
+				term = "!(" + term + ")"
+			}
+			unsupported = append(unsupported, term)
+			continue
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, unsupported
+}
+
+func parseWaapConditionCELTerm(term string, negation bool) (waap.CustomRuleConditionInput, bool) {
+	result := waap.CustomRuleConditionInput{}
+
+	if alternatives := splitCELTopLevel(term, "||"); len(alternatives) > 1 {
+		object := ""
+		tags := []string{}
+		for _, alternative := range alternatives {
+			match := celTagsExistsRe.FindStringSubmatch(alternative)
+			if match == nil || (object != "" && object != match[1]) {
+				return result, false
+			}
+			object = match[1]
+			tags = append(tags, celUnquote(match[2]))
+		}
+		return waapTagsCondition(object, tags, negation), true
+	}
+
+	if match := celTagsExistsRe.FindStringSubmatch(term); match != nil {
+		return waapTagsCondition(match[1], []string{celUnquote(match[2])}, negation), true
+	}
+
+	if match := celCompareRe.FindStringSubmatch(term); match != nil {
+		field, operator, value := match[1], match[4], celUnquote(match[5])
+		if operator == "!=" {
+			negation = !negation
+		}
+		exact := "Exact"
+		switch celCompareFields[field] {
+		case "ip":
+			result.Ip = &waap.IpCondition{IpAddress: value, Negation: &negation}
+		case "http_method":
+			result.HttpMethod = &waap.HttpMethodCondition{HttpMethod: waap.HTTPMethod(strings.ToUpper(value)), Negation: &negation}
+		case "url":
+			matchType := waap.UrlConditionMatchType(exact)
+			result.Url = &waap.UrlCondition{Url: value, MatchType: &matchType, Negation: &negation}
+		case "organization":
+			result.Organization = &waap.OrganizationCondition{Organization: value, Negation: &negation}
+		default:
+			return waapHeaderCondition(field, exact, value, negation)
+		}
+		return result, true
+	}
+
+	if match := celCallRe.FindStringSubmatch(term); match != nil {
+		field, value := match[1], celUnquote(match[5])
+		matchType := "Contains"
+		if match[4] == "matches" {
+			matchType = "Regex"
+		}
+		if field == "request.path" {
+			urlMatchType := waap.UrlConditionMatchType(matchType)
+			result.Url = &waap.UrlCondition{Url: value, MatchType: &urlMatchType, Negation: &negation}
+			return result, true
+		}
+		return waapHeaderCondition(field, matchType, value, negation)
+	}
+
+	if match := celIPRangeRe.FindStringSubmatch(term); match != nil {
+		result.IpRange = &waap.IpRangeCondition{
+			LowerBound: celUnquote(match[1]),
+			UpperBound: celUnquote(match[2]),
+			Negation:   &negation,
+		}
+		return result, true
+	}
+
+	if match := celHeaderExistsRe.FindStringSubmatch(term); match != nil {
+		header := celUnquote(match[1])
+		if match[2] == "response" {
+			result.ResponseHeaderExists = &waap.ResponseHeaderExistsCondition{Header: header, Negation: &negation}
+		} else {
+			result.HeaderExists = &waap.HeaderExistsCondition{Header: header, Negation: &negation}
+		}
+		return result, true
+	}
+
+	if match := celInListRe.FindStringSubmatch(term); match != nil {
+		values := []string{}
+		for _, item := range celListItemRe.FindAllString(match[2], -1) {
+			values = append(values, celUnquote(item))
+		}
+		switch match[1] {
+		case "request.file_extension":
+			result.FileExtension = &waap.FileExtensionCondition{FileExtension: values, Negation: &negation}
+		case "request.content_type":
+			result.ContentType = &waap.ContentTypeCondition{ContentType: values, Negation: &negation}
+		case "whois.country":
+			result.Country = &waap.CountryCondition{CountryCode: values, Negation: &negation}
+		case "whois.owner_type":
+			ownerTypes := []waap.OwnerTypesConditionOwnerTypes{}
+			for _, v := range values {
+				ownerTypes = append(ownerTypes, waap.OwnerTypesConditionOwnerTypes(v))
+			}
+			result.OwnerTypes = &waap.OwnerTypesCondition{OwnerTypes: &ownerTypes, Negation: &negation}
+		}
+		return result, true
+	}
+
+	if match := celRequestCountRe.FindStringSubmatch(term); match != nil {
+		count, err := strconv.Atoi(match[1])
+		if err != nil {
+			return result, false
+		}
+		result.SessionRequestCount = &waap.SessionRequestCountCondition{RequestCount: count, Negation: &negation}
+		return result, true
+	}
+
+	return result, false
+}
+
+func waapTagsCondition(object string, tags []string, negation bool) waap.CustomRuleConditionInput {
+	result := waap.CustomRuleConditionInput{}
+	if object == "user_defined_tags" {
+		result.UserDefinedTags = &waap.UserDefinedTagsCondition{Tags: tags, Negation: &negation}
+	} else {
+		result.Tags = &waap.TagsCondition{Tags: tags, Negation: &negation}
+	}
+	return result
+}
+
+// waapHeaderCondition maps a request.headers or response.headers term to a header condition.
+// The User-Agent request header is reported as a user agent condition.
+func waapHeaderCondition(field, matchType, value string, negation bool) (waap.CustomRuleConditionInput, bool) {
+	result := waap.CustomRuleConditionInput{}
+
+	match := celHeaderKeyRe.FindStringSubmatch(field)
+	if match == nil {
+		return result, false
+	}
+	header := celUnquote(match[2])
+
+	switch {
+	case matchType == "Regex":
+		// Request and response headers can't be matched by a regular expression in Custom Rules
+		return result, false
+	case match[1] == "response":
+		responseMatchType := waap.ResponseHeaderConditionMatchType(matchType)
+		result.ResponseHeader = &waap.ResponseHeaderCondition{Header: header, Value: value, MatchType: &responseMatchType, Negation: &negation}
+	case strings.EqualFold(header, "User-Agent"):
+		agentMatchType := waap.UserAgentConditionMatchType(matchType)
+		result.UserAgent = &waap.UserAgentCondition{UserAgent: value, MatchType: &agentMatchType, Negation: &negation}
+	default:
+		headerMatchType := waap.HeaderConditionMatchType(matchType)
+		result.Header = &waap.HeaderCondition{Header: header, Value: value, MatchType: &headerMatchType, Negation: &negation}
+	}
+
+	return result, true
+}
+
+// splitCELTopLevel splits the expression by the operator, ignoring operators inside string literals and brackets.
+func splitCELTopLevel(expression, operator string) []string {
+	parts := []string{}
+	depth := 0
+	var quote byte
+	start := 0
+
+	for i := 0; i < len(expression); i++ {
+		ch := expression[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(' || ch == '[' || ch == '{':
+			depth++
+		case ch == ')' || ch == ']' || ch == '}':
+			depth--
+		case depth == 0 && strings.HasPrefix(expression[i:], operator):
+			parts = append(parts, strings.TrimSpace(expression[start:i]))
+			i += len(operator) - 1
+			start = i + 1
+		}
+	}
+	parts = append(parts, strings.TrimSpace(expression[start:]))
+
+	result := parts[:0]
+	for _, part := range parts {
+		if part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// unwrapCELParens strips the parentheses enclosing the whole term.
+func unwrapCELParens(term string) (string, bool) {
+	if !strings.HasPrefix(term, "(") || !strings.HasSuffix(term, ")") {
+		return term, false
+	}
+
+	depth := 0
+	var quote byte
+	for i := 0; i < len(term); i++ {
+		ch := term[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+			if depth == 0 && i != len(term)-1 {
+				return term, false
+			}
+		}
+	}
+
+	return strings.TrimSpace(term[1 : len(term)-1]), true
+}
+
+// waapConditionInputToOutput allows to reuse readConditionsFromResponse for the converted conditions.
+func waapConditionInputToOutput(c waap.CustomRuleConditionInput) waap.CustomRuleConditionOutput {
+	return waap.CustomRuleConditionOutput{
+		ContentType:          c.ContentType,
+		Country:              c.Country,
+		FileExtension:        c.FileExtension,
+		Header:               c.Header,
+		HeaderExists:         c.HeaderExists,
+		HttpMethod:           c.HttpMethod,
+		Ip:                   c.Ip,
+		IpRange:              c.IpRange,
+		Organization:         c.Organization,
+		OwnerTypes:           c.OwnerTypes,
+		RequestRate:          c.RequestRate,
+		ResponseHeader:       c.ResponseHeader,
+		ResponseHeaderExists: c.ResponseHeaderExists,
+		SessionRequestCount:  c.SessionRequestCount,
+		Tags:                 c.Tags,
+		Url:                  c.Url,
+		UserAgent:            c.UserAgent,
+		UserDefinedTags:      c.UserDefinedTags,
+	}
+}
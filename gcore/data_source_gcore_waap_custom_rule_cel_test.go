@@ -0,0 +1,82 @@
+package gcore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWaapConditionsCELRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{
+			name:   "ip and negated url",
+			source: "request.ip == '10.0.0.1' && !(request.path.contains('/admin'))",
+		},
+		{
+			name:   "headers",
+			source: "request.headers['X-Api-Key'] == 'secret' && 'Cookie' in request.headers && response.headers['Server'].contains('nginx')",
+		},
+		{
+			name:   "user agent",
+			source: "request.headers['User-Agent'].contains('curl')",
+		},
+		{
+			name:   "lists",
+			source: "whois.country in ['DE', 'US'] && request.file_extension in ['js'] && whois.owner_type in ['HOSTING_SERVICES']",
+		},
+		{
+			name:   "tags",
+			source: "(tags.exists('a') || tags.exists('b')) && !(user_defined_tags.exists('c'))",
+		},
+		{
+			name:   "ip range and session",
+			source: "request.ip_in_range('10.0.0.1', '10.0.0.10') && session.request_count >= 5 && request.method == 'POST'",
+		},
+		{
+			name:   "escaped quotes",
+			source: `whois.org == 'O\'Reilly'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, unsupported := parseWaapConditionsCEL(tt.source)
+			if len(unsupported) > 0 {
+				t.Fatalf("unexpected unsupported terms: %v", unsupported)
+			}
+
+			source, unsupported := waapConditionsToCEL(sortCustomRuleConditions(conditions))
+			if len(unsupported) > 0 {
+				t.Fatalf("unexpected unsupported conditions: %v", unsupported)
+			}
+
+			again, _ := parseWaapConditionsCEL(source)
+			if diff := cmp.Diff(sortCustomRuleConditions(conditions), sortCustomRuleConditions(again)); diff != "" {
+				t.Errorf("conditions changed after round trip (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseWaapConditionsCELUnsupported(t *testing.T) {
+	source := "request.ip != '10.0.0.1' && (request.path == '/a' || request.path == '/b') && request.uri.startsWith('/api') && " +
+		"response.headers['Server'].matches('^nginx')"
+
+	conditions, unsupported := parseWaapConditionsCEL(source)
+
+	if len(conditions) != 1 || conditions[0].Ip == nil || !*conditions[0].Ip.Negation {
+		t.Errorf("expected a single negated ip condition, got %+v", conditions)
+	}
+
+	want := []string{
+		"request.path == '/a' || request.path == '/b'",
+		"request.uri.startsWith('/api')",
+		"response.headers['Server'].matches('^nginx')",
+	}
+	if diff := cmp.Diff(want, unsupported); diff != "" {
+		t.Errorf("unexpected unsupported terms (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,254 @@
+package gcore
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	faaSBuildStatusFailed = "failed"
+	// faaSBuildLogExcerptLines is the number of trailing builder log lines shown on build failures
+	faaSBuildLogExcerptLines = 20
+
+	faaSTriggerTypeCron = "cron"
+	faaSTriggerTypeHTTP = "http"
+)
+
+// faaSArchiveModified is the modification time of every archive entry, so that the same files give the same archive
+var faaSArchiveModified = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// faasFunctionCreateOpts extends faas.CreateFunctionOpts with the zipped function code
+type faasFunctionCreateOpts struct {
+	faas.CreateFunctionOpts
+	CodeArchive []byte
+}
+
+func (opts faasFunctionCreateOpts) ToFunctionCreateMap() (map[string]interface{}, error) {
+	mp, err := opts.CreateFunctionOpts.ToFunctionCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.CodeArchive != nil {
+		delete(mp, "code_text")
+		mp["code_archive"] = base64.StdEncoding.EncodeToString(opts.CodeArchive)
+	}
+	return mp, nil
+}
+
+// faasFunctionUpdateOpts extends faas.UpdateFunctionOpts with the zipped function code
+type faasFunctionUpdateOpts struct {
+	faas.UpdateFunctionOpts
+	CodeArchive []byte
+}
+
+func (opts faasFunctionUpdateOpts) ToFunctionUpdateMap() (map[string]interface{}, error) {
+	mp, err := opts.UpdateFunctionOpts.ToFunctionUpdateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.CodeArchive != nil {
+		mp["code_archive"] = base64.StdEncoding.EncodeToString(opts.CodeArchive)
+	}
+	return mp, nil
+}
+
+// loadFaaSFunctionSource returns the zipped function code from source_dir or source_archive, nil for inline code
+func loadFaaSFunctionSource(d interface{ Get(string) interface{} }) ([]byte, error) {
+	if dir := d.Get("source_dir").(string); dir != "" {
+		return zipFaaSSourceDir(dir)
+	}
+	if archive := d.Get("source_archive").(string); archive != "" {
+		payload, err := os.ReadFile(archive)
+		if err != nil {
+			return nil, fmt.Errorf("opening file %s: %w", archive, err)
+		}
+		return payload, nil
+	}
+	return nil, nil
+}
+
+// zipFaaSSourceDir zips the files of the directory in lexical order with fixed timestamps and permissions,
+// so that the archive and its hash only change with the file names and contents
+func zipFaaSSourceDir(dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		// symlinks are followed, so the linked file content is archived
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", path)
+		}
+		mode := fs.FileMode(0644)
+		if info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		header := &zip.FileHeader{
+			Name:     filepath.ToSlash(rel),
+			Method:   zip.Deflate,
+			Modified: faaSArchiveModified,
+		}
+		header.SetMode(mode)
+		fw, err := w.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = fw.Write(content)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("packaging source_dir %s: %w", dir, err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("packaging source_dir %s: %w", dir, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// faaSBuildDiagnostics reports a failed function build with the tail of the builder log
+func faaSBuildDiagnostics(function *faas.Function, severity diag.Severity) diag.Diagnostics {
+	if !strings.EqualFold(function.BuildStatus, faaSBuildStatusFailed) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: severity,
+		Summary:  fmt.Sprintf("FaaS function %s build failed", function.Name),
+		Detail:   faaSBuildLogExcerpt(function.BuildMessage, faaSBuildLogExcerptLines),
+	}}
+}
+
+func faaSBuildLogExcerpt(message string, lines int) string {
+	all := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	if len(all) <= lines {
+		return strings.Join(all, "\n")
+	}
+	return "...\n" + strings.Join(all[len(all)-lines:], "\n")
+}
+
+// faasTriggerOpts represents options used to create or update a function trigger
+type faasTriggerOpts struct {
+	Name         string   `json:"name,omitempty"`
+	Type         string   `json:"type,omitempty"`
+	Schedule     string   `json:"schedule,omitempty"`
+	Timezone     string   `json:"timezone,omitempty"`
+	Path         string   `json:"path,omitempty"`
+	Methods      []string `json:"methods,omitempty"`
+	EnableAPIKey bool     `json:"enable_api_key"`
+	Keys         []string `json:"keys"`
+	Disabled     bool     `json:"disabled"`
+}
+
+type faasTrigger struct {
+	Name         string                   `json:"name"`
+	Type         string                   `json:"type"`
+	Schedule     string                   `json:"schedule"`
+	Timezone     string                   `json:"timezone"`
+	Path         string                   `json:"path"`
+	Methods      []string                 `json:"methods"`
+	EnableAPIKey bool                     `json:"enable_api_key"`
+	Keys         []string                 `json:"keys"`
+	Disabled     bool                     `json:"disabled"`
+	Status       string                   `json:"status"`
+	Endpoint     string                   `json:"endpoint"`
+	CreatedAt    gcorecloud.JSONRFC3339ZZ `json:"created_at"`
+}
+
+// faasInvocation is a single run of a function, through its endpoint or a trigger
+type faasInvocation struct {
+	ID         string                   `json:"id"`
+	Trigger    string                   `json:"trigger"`
+	Status     string                   `json:"status"`
+	StatusCode int                      `json:"status_code"`
+	Error      string                   `json:"error"`
+	DurationMs int                      `json:"duration_ms"`
+	StartedAt  gcorecloud.JSONRFC3339ZZ `json:"started_at"`
+}
+
+func faasTriggersURL(client *gcorecloud.ServiceClient, nsName, fName string) string {
+	return client.ServiceURL(nsName, "functions", fName, "triggers")
+}
+
+// createFaaSTrigger adds a trigger to the function
+func createFaaSTrigger(client *gcorecloud.ServiceClient, nsName, fName string, opts faasTriggerOpts) (*faasTrigger, error) {
+	var trigger faasTrigger
+	_, err := client.Post(faasTriggersURL(client, nsName, fName), opts, &trigger, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusCreated},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+// getFaaSTrigger returns the trigger of the function
+func getFaaSTrigger(client *gcorecloud.ServiceClient, nsName, fName, name string) (*faasTrigger, error) {
+	var trigger faasTrigger
+	if _, err := client.Get(client.ServiceURL(nsName, "functions", fName, "triggers", name), &trigger, nil); err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+// updateFaaSTrigger replaces the trigger settings
+func updateFaaSTrigger(client *gcorecloud.ServiceClient, nsName, fName, name string, opts faasTriggerOpts) error {
+	_, err := client.Patch(client.ServiceURL(nsName, "functions", fName, "triggers", name), opts, nil, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK},
+	})
+	return err
+}
+
+// deleteFaaSTrigger removes the trigger from the function
+func deleteFaaSTrigger(client *gcorecloud.ServiceClient, nsName, fName, name string) error {
+	_, err := client.Delete(client.ServiceURL(nsName, "functions", fName, "triggers", name), nil)
+	return err
+}
+
+// lastFaaSInvocation returns the latest invocation of the function, only of the given trigger if set, nil if there is none
+func lastFaaSInvocation(client *gcorecloud.ServiceClient, nsName, fName, trigger string) (*faasInvocation, error) {
+	query := url.Values{}
+	query.Set("limit", "1")
+	query.Set("order", "-started_at")
+	if trigger != "" {
+		query.Set("trigger", trigger)
+	}
+	var body struct {
+		Results []faasInvocation `json:"results"`
+	}
+	invocationsURL := client.ServiceURL(nsName, "functions", fName, "invocations") + "?" + query.Encode()
+	if _, err := client.Get(invocationsURL, &body, nil); err != nil {
+		return nil, err
+	}
+	if len(body.Results) == 0 {
+		return nil, nil
+	}
+	return &body.Results[0], nil
+}
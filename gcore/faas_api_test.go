@@ -0,0 +1,167 @@
+package gcore
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+func TestZipFaaSSourceDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":          "package kubeless\n",
+		"lib/helper.go":    "package lib\n",
+		"lib/data/a.json":  "{}\n",
+		"requirements.txt": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first, err := zipFaaSSourceDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// file times and permissions do not change the archive
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "main.go"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(dir, "lib/helper.go"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	second, err := zipFaaSSourceDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sha256Checksum(first) != sha256Checksum(second) {
+		t.Errorf("archive changed without content change")
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(first), int64(len(first)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	want := []string{"lib/data/a.json", "lib/helper.go", "main.go", "requirements.txt"}
+	if len(names) != len(want) {
+		t.Fatalf("got entries %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got entries %v, want %v", names, want)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package changed\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	third, err := zipFaaSSourceDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sha256Checksum(first) == sha256Checksum(third) {
+		t.Errorf("archive did not change with content change")
+	}
+}
+
+func TestFaaSBuildLogExcerpt(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		lines   int
+		want    string
+	}{
+		{name: "short", message: "a\nb\n", lines: 3, want: "a\nb"},
+		{name: "truncated", message: "a\nb\nc\nd", lines: 2, want: "...\nc\nd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := faaSBuildLogExcerpt(tt.message, tt.lines); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastFaaSInvocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ns/functions/fn/invocations" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("trigger"); got != "nightly" {
+			t.Errorf("trigger = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"results":[
+			{"id":"inv","trigger":"nightly","status":"failed","status_code":500,"started_at":"2024-03-01T03:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	client := &gcorecloud.ServiceClient{ProviderClient: &gcorecloud.ProviderClient{}, Endpoint: server.URL + "/"}
+	invocation, err := lastFaaSInvocation(client, "ns", "fn", "nightly")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if invocation == nil || invocation.ID != "inv" || invocation.StatusCode != 500 {
+		t.Errorf("unexpected invocation: %+v", invocation)
+	}
+}
+
+func TestFaaSTriggerAPI(t *testing.T) {
+	client := newAPIFixtureClient(t,
+		apiFixture{
+			method: http.MethodPost, path: "/ns/functions/fn/triggers", status: http.StatusCreated,
+			request: `{"name":"nightly","type":"cron","schedule":"0 3 * * *","timezone":"UTC",
+				"enable_api_key":false,"keys":null,"disabled":false}`,
+			response: `{"name":"nightly","type":"cron","schedule":"0 3 * * *","timezone":"UTC","status":"active",
+				"created_at":"2024-03-01T03:00:00Z"}`,
+		},
+		apiFixture{
+			method: http.MethodGet, path: "/ns/functions/fn/triggers/nightly", status: http.StatusOK,
+			response: `{"name":"nightly","type":"cron","schedule":"0 3 * * *","timezone":"UTC","status":"active",
+				"created_at":"2024-03-01T03:00:00Z"}`,
+		},
+		apiFixture{
+			method: http.MethodPatch, path: "/ns/functions/fn/triggers/nightly", status: http.StatusOK,
+			request:  `{"schedule":"0 4 * * *","enable_api_key":false,"keys":null,"disabled":true}`,
+			response: `{}`,
+		},
+		apiFixture{
+			method: http.MethodDelete, path: "/ns/functions/fn/triggers/nightly", status: http.StatusNoContent,
+		},
+	)
+
+	trigger, err := createFaaSTrigger(client, "ns", "fn", faasTriggerOpts{
+		Name: "nightly", Type: faaSTriggerTypeCron, Schedule: "0 3 * * *", Timezone: "UTC",
+	})
+	if err != nil || trigger.Status != "active" {
+		t.Errorf("createFaaSTrigger = %+v, %v", trigger, err)
+	}
+	trigger, err = getFaaSTrigger(client, "ns", "fn", "nightly")
+	if err != nil || trigger.Schedule != "0 3 * * *" || trigger.CreatedAt.IsZero() {
+		t.Errorf("getFaaSTrigger = %+v, %v", trigger, err)
+	}
+	if err := updateFaaSTrigger(client, "ns", "fn", "nightly", faasTriggerOpts{Schedule: "0 4 * * *", Disabled: true}); err != nil {
+		t.Errorf("updateFaaSTrigger: %v", err)
+	}
+	if err := deleteFaaSTrigger(client, "ns", "fn", "nightly"); err != nil {
+		t.Errorf("deleteFaaSTrigger: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// Package fastedgelocal runs FastEdge applications locally, so configurations can be tested without deploying them.
+//
+// Only proxy-wasm binaries are supported: the application gets env as WASI environment variables,
+// secrets via proxy_get_secret and env via proxy_dictionary_get, and rsp_headers are set on every response,
+// the same way FastEdge does. Header names are lowercased, as in HTTP/2.
+package fastedgelocal
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// ErrComponent is returned for WebAssembly components (wasi-http apps), which cannot be run locally
+var ErrComponent = errors.New("WebAssembly components cannot be run locally, only proxy-wasm modules are supported")
+
+// ErrNotProxyWasm is returned for core modules that do not implement the proxy-wasm ABI
+var ErrNotProxyWasm = errors.New("WebAssembly module does not export proxy_on_request_headers")
+
+// App is a FastEdge application configuration, as in the gcore_fastedge_app resource
+type App struct {
+	Binary     []byte
+	Env        map[string]string
+	Secrets    map[string]string
+	RspHeaders map[string]string
+	// Log receives application logs and WASI stdout/stderr, discarded if nil
+	Log io.Writer
+}
+
+// Request is an HTTP request sent to the application
+type Request struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// Response is an HTTP response, either returned by the origin or sent by the application
+type Response struct {
+	Status  int
+	Headers map[string]string
+	Body    []byte
+}
+
+// Origin returns the upstream response for a request the application lets through
+type Origin func(req *Request) *Response
+
+// Run sends the request through the application. If the application does not reply itself,
+// the request, as modified by the application, is passed to the origin.
+func Run(ctx context.Context, app App, req Request, origin Origin) (*Response, error) {
+	if len(app.Binary) >= 8 && app.Binary[6] == 1 { // layer field of the binary header
+		return nil, ErrComponent
+	}
+	if origin == nil {
+		origin = func(*Request) *Response { return &Response{Status: http.StatusOK} }
+	}
+	logw := app.Log
+	if logw == nil {
+		logw = io.Discard
+	}
+
+	h, err := newHost(app, req, logw)
+	if err != nil {
+		return nil, err
+	}
+
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+	defer r.Close(ctx)
+
+	compiled, err := r.CompileModule(ctx, app.Binary)
+	if err != nil {
+		return nil, fmt.Errorf("compiling WebAssembly module: %w", err)
+	}
+	if _, ok := compiled.ExportedFunctions()["proxy_on_request_headers"]; !ok {
+		return nil, ErrNotProxyWasm
+	}
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		return nil, fmt.Errorf("instantiating WASI: %w", err)
+	}
+	if err := h.instantiate(ctx, r, compiled); err != nil {
+		return nil, fmt.Errorf("instantiating proxy-wasm host: %w", err)
+	}
+
+	config := wazero.NewModuleConfig().
+		WithName("app").
+		WithStartFunctions().
+		WithStdout(logw).
+		WithStderr(logw).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+	for k, v := range app.Env {
+		config = config.WithEnv(k, v)
+	}
+	h.mod, err = r.InstantiateModule(ctx, compiled, config)
+	if err != nil {
+		return nil, fmt.Errorf("instantiating WebAssembly module: %w", err)
+	}
+	for _, start := range []string{"_initialize", "_start"} {
+		if fn := h.mod.ExportedFunction(start); fn != nil {
+			var exitErr *sys.ExitError
+			if _, err := fn.Call(ctx); err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 0) {
+				return nil, fmt.Errorf("%s: %w", start, err)
+			}
+			break
+		}
+	}
+
+	return h.handle(ctx, origin)
+}
+
+const (
+	rootContextID = 1
+	httpContextID = 2
+)
+
+// handle runs the proxy-wasm request lifecycle
+func (h *host) handle(ctx context.Context, origin Origin) (*Response, error) {
+	if _, err := h.call(ctx, "proxy_on_context_create", rootContextID, 0); err != nil {
+		return nil, err
+	}
+	for _, start := range []string{"proxy_on_vm_start", "proxy_on_configure"} {
+		if ok, err := h.call(ctx, start, rootContextID, 0); err != nil {
+			return nil, err
+		} else if ok == 0 && h.mod.ExportedFunction(start) != nil {
+			return nil, fmt.Errorf("%s: application failed to start", start)
+		}
+	}
+	if _, err := h.call(ctx, "proxy_on_context_create", httpContextID, rootContextID); err != nil {
+		return nil, err
+	}
+
+	phases := []struct {
+		headers, body string
+		headerMap     uint32
+		bodyBuffer    uint32
+	}{
+		{"proxy_on_request_headers", "proxy_on_request_body", mapRequestHeaders, bufferRequestBody},
+		{"proxy_on_response_headers", "proxy_on_response_body", mapResponseHeaders, bufferResponseBody},
+	}
+	for i, phase := range phases {
+		if i == 1 {
+			upstream := origin(h.request())
+			if upstream == nil {
+				upstream = &Response{Status: http.StatusOK}
+			}
+			h.setResponse(upstream)
+		}
+
+		body := h.buffers[phase.bodyBuffer]
+		endOfStream := boolToU32(len(body) == 0)
+		if _, err := h.call(ctx, phase.headers, httpContextID, uint32(len(h.headers[phase.headerMap])), endOfStream); err != nil {
+			return nil, err
+		}
+		if h.local == nil && len(body) > 0 {
+			if _, err := h.call(ctx, phase.body, httpContextID, uint32(len(body)), 1); err != nil {
+				return nil, err
+			}
+		}
+		if h.local != nil {
+			break
+		}
+	}
+
+	for _, done := range []string{"proxy_on_done", "proxy_on_log", "proxy_on_delete"} {
+		if _, err := h.call(ctx, done, httpContextID); err != nil {
+			return nil, err
+		}
+	}
+
+	rsp := h.local
+	if rsp == nil {
+		rsp = h.response()
+	}
+	for k, v := range h.app.RspHeaders {
+		rsp.Headers[strings.ToLower(k)] = v
+	}
+	return rsp, nil
+}
+
+// request returns the request as modified by the application
+func (h *host) request() *Request {
+	req := &Request{Headers: map[string]string{}, Body: h.buffers[bufferRequestBody]}
+	u := url.URL{}
+	for _, p := range h.headers[mapRequestHeaders] {
+		switch p.key {
+		case ":method":
+			req.Method = p.value
+		case ":scheme":
+			u.Scheme = p.value
+		case ":authority":
+			u.Host = p.value
+		case ":path":
+			if parsed, err := url.ParseRequestURI(p.value); err == nil {
+				u.Path, u.RawQuery = parsed.Path, parsed.RawQuery
+			}
+		default:
+			addHeader(req.Headers, p.key, p.value)
+		}
+	}
+	req.URL = u.String()
+	return req
+}
+
+// response returns the upstream response as modified by the application
+func (h *host) response() *Response {
+	rsp := &Response{Status: http.StatusOK, Headers: map[string]string{}, Body: h.buffers[bufferResponseBody]}
+	for _, p := range h.headers[mapResponseHeaders] {
+		if p.key == ":status" {
+			fmt.Sscan(p.value, &rsp.Status)
+			continue
+		}
+		addHeader(rsp.Headers, p.key, p.value)
+	}
+	return rsp
+}
+
+func (h *host) setResponse(rsp *Response) {
+	status := rsp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	pairs := []pair{{":status", fmt.Sprint(status)}}
+	for k, v := range rsp.Headers {
+		pairs = append(pairs, pair{strings.ToLower(k), v})
+	}
+	h.headers[mapResponseHeaders] = pairs
+	h.buffers[bufferResponseBody] = rsp.Body
+}
+
+func addHeader(headers map[string]string, key, value string) {
+	if prev, ok := headers[key]; ok {
+		value = prev + ", " + value
+	}
+	headers[key] = value
+}
+
+func boolToU32(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,181 @@
+package fastedgelocal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// testModule builds a proxy-wasm module which looks up key with the getter host function
+// (proxy_get_secret or proxy_dictionary_get) and replies 200 with the value. If the key is not found,
+// the request is passed to the origin and the "x-app: checked" response header is added.
+func testModule(getter, key string) []byte {
+	i32 := byte(0x7f)
+	section := func(id byte, items ...[]byte) []byte {
+		body := vec(items...)
+		return append(append([]byte{id}, uleb(uint32(len(body)))...), body...)
+	}
+	funcType := func(params, results int) []byte {
+		return append(append([]byte{0x60}, vec(repeat([]byte{i32}, params)...)...), vec(repeat([]byte{i32}, results)...)...)
+	}
+	imp := func(name string, typ byte) []byte {
+		return append(append(str("env"), str(name)...), 0x00, typ)
+	}
+	exp := func(name string, kind, idx byte) []byte {
+		return append(str(name), kind, idx)
+	}
+	code := func(ops ...byte) []byte {
+		body := append([]byte{0x00}, append(ops, 0x0b)...) // no locals
+		return append(uleb(uint32(len(body))), body...)
+	}
+	data := func(offset byte, value string) []byte {
+		return append([]byte{0x00, 0x41, offset, 0x0b}, str(value)...)
+	}
+	const (
+		i32Const = 0x41
+		i32Load  = 0x28
+		call     = 0x10
+		drop     = 0x1a
+	)
+
+	module := []byte("\x00asm\x01\x00\x00\x00")
+	module = append(module, section(1, // types
+		funcType(4, 1), funcType(8, 1), funcType(1, 1), funcType(2, 0), funcType(3, 1), funcType(5, 1))...)
+	module = append(module, section(2, // imports
+		imp(getter, 0), imp("proxy_send_local_response", 1), imp("proxy_add_header_map_value", 5))...)
+	module = append(module, section(3, []byte{2}, []byte{3}, []byte{4}, []byte{4})...)    // functions 3-6
+	module = append(module, section(5, []byte{0x00, 0x01})...)                            // memory, 1 page
+	module = append(module, section(6, []byte{i32, 0x01, i32Const, 0x80, 0x08, 0x0b})...) // heap = 1024
+	module = append(module, section(7,
+		exp("memory", 0x02, 0),
+		exp("proxy_on_memory_allocate", 0x00, 3),
+		exp("proxy_on_context_create", 0x00, 4),
+		exp("proxy_on_request_headers", 0x00, 5),
+		exp("proxy_on_response_headers", 0x00, 6))...)
+	module = append(module, section(10,
+		// allocate: return heap, heap += size
+		code(0x23, 0, 0x23, 0, 0x20, 0, 0x6a, 0x24, 0),
+		// context create
+		code(),
+		// request headers: if getter(key) == OK, send the value, stored at mem[0] (ptr) and mem[4] (size)
+		code(i32Const, 16, i32Const, byte(len(key)), i32Const, 0, i32Const, 4, call, 0,
+			0x45, 0x04, 0x40, // i32.eqz, if
+			i32Const, 0xc8, 0x01, i32Const, 0, i32Const, 0,
+			i32Const, 0, i32Load, 2, 0, i32Const, 4, i32Load, 2, 0,
+			i32Const, 0, i32Const, 0, i32Const, 0x7f, call, 1, drop,
+			i32Const, 1, 0x0f, // return Pause
+			0x0b, i32Const, 0),
+		// response headers: add "x-app: checked"
+		code(i32Const, 2, i32Const, 40, i32Const, 5, i32Const, 48, i32Const, 7, call, 2, drop, i32Const, 0))...)
+	module = append(module, section(11, data(16, key), data(40, "x-app"), data(48, "checked"))...)
+	return module
+}
+
+func vec(items ...[]byte) []byte {
+	return append(uleb(uint32(len(items))), bytes.Join(items, nil)...)
+}
+
+func str(s string) []byte {
+	return append(uleb(uint32(len(s))), s...)
+}
+
+func uleb(v uint32) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func repeat(b []byte, n int) [][]byte {
+	out := make([][]byte, n)
+	for i := range out {
+		out[i] = b
+	}
+	return out
+}
+
+func TestRun(t *testing.T) {
+	origin := func(req *Request) *Response {
+		return &Response{
+			Status:  http.StatusCreated,
+			Headers: map[string]string{"Content-Type": "text/plain"},
+			Body:    []byte(req.Method + " " + req.URL + " " + req.Headers["x-test"]),
+		}
+	}
+	req := Request{URL: "https://example.com/path?q=1", Headers: map[string]string{"X-Test": "yes"}}
+
+	tests := []struct {
+		name        string
+		app         App
+		wantStatus  int
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "secret",
+			app:         App{Binary: testModule("proxy_get_secret", "token"), Secrets: map[string]string{"token": "s3cr3t"}, RspHeaders: map[string]string{"X-Frame-Options": "DENY"}},
+			wantStatus:  http.StatusOK,
+			wantBody:    "s3cr3t",
+			wantHeaders: map[string]string{"x-frame-options": "DENY"},
+		},
+		{
+			name:       "env",
+			app:        App{Binary: testModule("proxy_dictionary_get", "greeting"), Env: map[string]string{"greeting": "hello"}},
+			wantStatus: http.StatusOK,
+			wantBody:   "hello",
+		},
+		{
+			name:        "origin",
+			app:         App{Binary: testModule("proxy_get_secret", "token"), RspHeaders: map[string]string{"X-Frame-Options": "DENY"}},
+			wantStatus:  http.StatusCreated,
+			wantBody:    "GET https://example.com/path?q=1 yes",
+			wantHeaders: map[string]string{"content-type": "text/plain", "x-app": "checked", "x-frame-options": "DENY"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rsp, err := Run(context.Background(), tt.app, req, origin)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rsp.Status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rsp.Status, tt.wantStatus)
+			}
+			if string(rsp.Body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", rsp.Body, tt.wantBody)
+			}
+			for k, v := range tt.wantHeaders {
+				if rsp.Headers[k] != v {
+					t.Errorf("header %s = %q, want %q", k, rsp.Headers[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestRunInvalidBinary(t *testing.T) {
+	req := Request{URL: "http://localhost/"}
+
+	_, err := Run(context.Background(), App{Binary: []byte("\x00asm\x0d\x00\x01\x00")}, req, nil)
+	if !errors.Is(err, ErrComponent) {
+		t.Errorf("expected ErrComponent, got: %v", err)
+	}
+
+	_, err = Run(context.Background(), App{Binary: []byte("\x00asm\x01\x00\x00\x00")}, req, nil)
+	if !errors.Is(err, ErrNotProxyWasm) {
+		t.Errorf("expected ErrNotProxyWasm, got: %v", err)
+	}
+
+	_, err = Run(context.Background(), App{Binary: []byte("not wasm")}, req, nil)
+	if err == nil || !strings.Contains(err.Error(), "compiling") {
+		t.Errorf("expected compile error, got: %v", err)
+	}
+}
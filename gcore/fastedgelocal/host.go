@@ -0,0 +1,493 @@
+package fastedgelocal
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// proxy-wasm ABI constants
+const (
+	statusOK                  = 0
+	statusNotFound            = 1
+	statusBadArgument         = 2
+	statusInvalidMemoryAccess = 6
+	statusInternalFailure     = 10
+	statusUnimplemented       = 12
+
+	mapRequestHeaders  = 0
+	mapResponseHeaders = 2
+
+	bufferRequestBody  = 0
+	bufferResponseBody = 1
+)
+
+var logLevels = []string{"trace", "debug", "info", "warn", "error", "critical"}
+
+type pair struct {
+	key, value string
+}
+
+// host keeps the state of a single request handled by the application
+type host struct {
+	app        App
+	log        io.Writer
+	mod        api.Module
+	headers    map[uint32][]pair
+	buffers    map[uint32][]byte
+	properties map[string][]byte
+	local      *Response
+}
+
+func newHost(app App, req Request, log io.Writer) (*host, error) {
+	method := req.Method
+	if method == "" {
+		method = "GET"
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid request URL %q", req.URL)
+	}
+
+	headers := []pair{
+		{":method", method},
+		{":path", u.RequestURI()},
+		{":authority", u.Host},
+		{":scheme", u.Scheme},
+	}
+	for k, v := range req.Headers {
+		headers = append(headers, pair{strings.ToLower(k), v})
+	}
+
+	return &host{
+		app:     app,
+		log:     log,
+		headers: map[uint32][]pair{mapRequestHeaders: headers},
+		buffers: map[uint32][]byte{bufferRequestBody: req.Body},
+		properties: map[string][]byte{
+			"request.method":    []byte(method),
+			"request.url":       []byte(u.String()),
+			"request.host":      []byte(u.Host),
+			"request.scheme":    []byte(u.Scheme),
+			"request.path":      []byte(u.Path),
+			"request.query":     []byte(u.RawQuery),
+			"request.extension": []byte(strings.TrimPrefix(path.Ext(u.Path), ".")),
+		},
+	}, nil
+}
+
+// call calls an exported proxy-wasm callback, missing callbacks are skipped
+func (h *host) call(ctx context.Context, name string, args ...uint32) (uint32, error) {
+	fn := h.mod.ExportedFunction(name)
+	if fn == nil {
+		return 0, nil
+	}
+	params := make([]uint64, len(fn.Definition().ParamTypes()))
+	for i := range params {
+		if i < len(args) {
+			params[i] = api.EncodeU32(args[i])
+		}
+	}
+	res, err := fn.Call(ctx, params...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return api.DecodeU32(res[0]), nil
+}
+
+type hostFunc func(ctx context.Context, mod api.Module, args []uint32) uint32
+
+// instantiate defines the "env" module with the proxy-wasm host functions imported by the application.
+// Functions the runner does not implement return Unimplemented.
+func (h *host) instantiate(ctx context.Context, r wazero.Runtime, compiled wazero.CompiledModule) error {
+	funcs := h.funcs()
+	builder := r.NewHostModuleBuilder("env")
+	for _, def := range compiled.ImportedFunctions() {
+		module, name, _ := def.Import()
+		if module != "env" {
+			continue
+		}
+		params, results := def.ParamTypes(), def.ResultTypes()
+		impl, ok := funcs[name]
+		if ok && (len(params) != impl.params || !allI32(params) || len(results) != 1 || results[0] != api.ValueTypeI32) {
+			return fmt.Errorf("function %s has unexpected signature", name)
+		}
+		fn := api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+			if len(results) > 0 {
+				stack[0] = statusUnimplemented
+			}
+		})
+		if ok {
+			fn = func(ctx context.Context, mod api.Module, stack []uint64) {
+				args := make([]uint32, len(params))
+				for i := range args {
+					args[i] = api.DecodeU32(stack[i])
+				}
+				stack[0] = api.EncodeU32(impl.fn(ctx, mod, args))
+			}
+		}
+		builder.NewFunctionBuilder().WithGoModuleFunction(fn, params, results).Export(name)
+	}
+	_, err := builder.Instantiate(ctx)
+	return err
+}
+
+func allI32(types []api.ValueType) bool {
+	for _, t := range types {
+		if t != api.ValueTypeI32 {
+			return false
+		}
+	}
+	return true
+}
+
+// hostFuncDef is a host function taking params i32 arguments and returning the i32 status
+type hostFuncDef struct {
+	params int
+	fn     hostFunc
+}
+
+func (h *host) funcs() map[string]hostFuncDef {
+	ok := func(context.Context, api.Module, []uint32) uint32 { return statusOK }
+	return map[string]hostFuncDef{
+		"proxy_log":                          {3, h.proxyLog},
+		"proxy_get_log_level":                {1, h.proxyGetLogLevel},
+		"proxy_get_current_time_nanoseconds": {1, h.proxyGetCurrentTime},
+		"proxy_set_tick_period_milliseconds": {1, ok},
+		"proxy_set_effective_context":        {1, ok},
+		"proxy_continue_stream":              {1, ok},
+		"proxy_close_stream":                 {1, ok},
+		"proxy_continue_request":             {0, ok},
+		"proxy_continue_response":            {0, ok},
+		"proxy_done":                         {0, ok},
+		"proxy_get_header_map_pairs":         {3, h.proxyGetHeaderMapPairs},
+		"proxy_set_header_map_pairs":         {3, h.proxySetHeaderMapPairs},
+		"proxy_get_header_map_size":          {2, h.proxyGetHeaderMapSize},
+		"proxy_get_header_map_value":         {5, h.proxyGetHeaderMapValue},
+		"proxy_add_header_map_value":         {5, h.proxyAddHeaderMapValue},
+		"proxy_replace_header_map_value":     {5, h.proxyReplaceHeaderMapValue},
+		"proxy_remove_header_map_value":      {3, h.proxyRemoveHeaderMapValue},
+		"proxy_get_buffer_bytes":             {5, h.proxyGetBufferBytes},
+		"proxy_get_buffer_status":            {3, h.proxyGetBufferStatus},
+		"proxy_set_buffer_bytes":             {5, h.proxySetBufferBytes},
+		"proxy_send_local_response":          {8, h.proxySendLocalResponse},
+		"proxy_get_property":                 {4, h.proxyGetProperty},
+		"proxy_set_property":                 {4, h.proxySetProperty},
+		"proxy_get_secret":                   {4, h.lookup(h.app.Secrets)},
+		"proxy_dictionary_get":               {4, h.lookup(h.app.Env)},
+	}
+}
+
+func (h *host) proxyLog(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	msg, ok := mod.Memory().Read(args[1], args[2])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	level := "unknown"
+	if int(args[0]) < len(logLevels) {
+		level = logLevels[args[0]]
+	}
+	fmt.Fprintf(h.log, "[%s] %s\n", level, msg)
+	return statusOK
+}
+
+func (h *host) proxyGetLogLevel(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	return writeU32(mod, args[0], 0) // trace, log everything
+}
+
+func (h *host) proxyGetCurrentTime(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	if !mod.Memory().WriteUint64Le(args[0], uint64(time.Now().UnixNano())) {
+		return statusInvalidMemoryAccess
+	}
+	return statusOK
+}
+
+func (h *host) proxyGetHeaderMapPairs(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	pairs, ok := h.headers[args[0]]
+	if !ok && !knownMap(args[0]) {
+		return statusBadArgument
+	}
+	return returnBytes(ctx, mod, encodePairs(pairs), args[1], args[2])
+}
+
+func (h *host) proxySetHeaderMapPairs(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	if !knownMap(args[0]) {
+		return statusBadArgument
+	}
+	data, ok := mod.Memory().Read(args[1], args[2])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	pairs, ok := decodePairs(data)
+	if !ok {
+		return statusBadArgument
+	}
+	h.headers[args[0]] = pairs
+	return statusOK
+}
+
+func (h *host) proxyGetHeaderMapSize(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	if !knownMap(args[0]) {
+		return statusBadArgument
+	}
+	return writeU32(mod, args[1], uint32(len(encodePairs(h.headers[args[0]]))))
+}
+
+func (h *host) proxyGetHeaderMapValue(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	key, ok := readString(mod, args[1], args[2])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	if !knownMap(args[0]) {
+		return statusBadArgument
+	}
+	for _, p := range h.headers[args[0]] {
+		if p.key == strings.ToLower(key) {
+			return returnBytes(ctx, mod, []byte(p.value), args[3], args[4])
+		}
+	}
+	return statusNotFound
+}
+
+func (h *host) proxyAddHeaderMapValue(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	return h.setHeader(mod, args, false)
+}
+
+func (h *host) proxyReplaceHeaderMapValue(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	return h.setHeader(mod, args, true)
+}
+
+func (h *host) setHeader(mod api.Module, args []uint32, replace bool) uint32 {
+	key, ok := readString(mod, args[1], args[2])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	value, ok := readString(mod, args[3], args[4])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	if !knownMap(args[0]) {
+		return statusBadArgument
+	}
+	key = strings.ToLower(key)
+	if replace {
+		h.headers[args[0]] = removePairs(h.headers[args[0]], key)
+	}
+	h.headers[args[0]] = append(h.headers[args[0]], pair{key, value})
+	return statusOK
+}
+
+func (h *host) proxyRemoveHeaderMapValue(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	key, ok := readString(mod, args[1], args[2])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	if !knownMap(args[0]) {
+		return statusBadArgument
+	}
+	h.headers[args[0]] = removePairs(h.headers[args[0]], strings.ToLower(key))
+	return statusOK
+}
+
+func (h *host) proxyGetBufferBytes(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	data := h.buffers[args[0]]
+	start, size := args[1], args[2]
+	if start > uint32(len(data)) {
+		return statusBadArgument
+	}
+	data = data[start:]
+	if size < uint32(len(data)) {
+		data = data[:size]
+	}
+	return returnBytes(ctx, mod, data, args[3], args[4])
+}
+
+func (h *host) proxyGetBufferStatus(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	if status := writeU32(mod, args[1], uint32(len(h.buffers[args[0]]))); status != statusOK {
+		return status
+	}
+	return writeU32(mod, args[2], 0)
+}
+
+func (h *host) proxySetBufferBytes(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	data, ok := mod.Memory().Read(args[3], args[4])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	buf := h.buffers[args[0]]
+	start, size := args[1], args[2]
+	if start > uint32(len(buf)) {
+		return statusBadArgument
+	}
+	end := min(start+size, uint32(len(buf)))
+	h.buffers[args[0]] = append(append(append([]byte{}, buf[:start]...), data...), buf[end:]...)
+	return statusOK
+}
+
+func (h *host) proxySendLocalResponse(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	body, ok := mod.Memory().Read(args[3], args[4])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	headers, ok := mod.Memory().Read(args[5], args[6])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	pairs, ok := decodePairs(headers)
+	if !ok {
+		return statusBadArgument
+	}
+	rsp := &Response{Status: int(args[0]), Headers: map[string]string{}, Body: append([]byte{}, body...)}
+	for _, p := range pairs {
+		addHeader(rsp.Headers, strings.ToLower(p.key), p.value)
+	}
+	h.local = rsp
+	return statusOK
+}
+
+func (h *host) proxyGetProperty(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	name, ok := readString(mod, args[0], args[1])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	// property path segments are separated by zero bytes
+	name = strings.Trim(strings.ReplaceAll(name, "\x00", "."), ".")
+	if name == "response.status" && h.headers[mapResponseHeaders] != nil {
+		return returnBytes(ctx, mod, []byte(fmt.Sprint(h.response().Status)), args[2], args[3])
+	}
+	value, ok := h.properties[name]
+	if !ok {
+		return statusNotFound
+	}
+	return returnBytes(ctx, mod, value, args[2], args[3])
+}
+
+func (h *host) proxySetProperty(ctx context.Context, mod api.Module, args []uint32) uint32 {
+	name, ok := readString(mod, args[0], args[1])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	value, ok := mod.Memory().Read(args[2], args[3])
+	if !ok {
+		return statusInvalidMemoryAccess
+	}
+	h.properties[strings.Trim(strings.ReplaceAll(name, "\x00", "."), ".")] = append([]byte{}, value...)
+	return statusOK
+}
+
+// lookup implements FastEdge key-value host functions: proxy_get_secret and proxy_dictionary_get
+func (h *host) lookup(values map[string]string) hostFunc {
+	return func(ctx context.Context, mod api.Module, args []uint32) uint32 {
+		key, ok := readString(mod, args[0], args[1])
+		if !ok {
+			return statusInvalidMemoryAccess
+		}
+		value, ok := values[key]
+		if !ok {
+			return statusNotFound
+		}
+		return returnBytes(ctx, mod, []byte(value), args[2], args[3])
+	}
+}
+
+func knownMap(mapType uint32) bool {
+	return mapType <= 3 // request/response headers and trailers
+}
+
+func removePairs(pairs []pair, key string) []pair {
+	out := pairs[:0:0]
+	for _, p := range pairs {
+		if p.key != key {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// encodePairs serializes a header map: number of pairs, key and value sizes, then zero-terminated keys and values
+func encodePairs(pairs []pair) []byte {
+	buf := binary.LittleEndian.AppendUint32(nil, uint32(len(pairs)))
+	for _, p := range pairs {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(p.key)))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(p.value)))
+	}
+	for _, p := range pairs {
+		buf = append(append(buf, p.key...), 0)
+		buf = append(append(buf, p.value...), 0)
+	}
+	return buf
+}
+
+func decodePairs(data []byte) ([]pair, bool) {
+	if len(data) == 0 {
+		return nil, true
+	}
+	if len(data) < 4 {
+		return nil, false
+	}
+	n := int(binary.LittleEndian.Uint32(data))
+	if 4+8*n > len(data) {
+		return nil, false
+	}
+	sizes, data := data[4:], data[4+8*n:]
+	pairs := make([]pair, n)
+	for i := range pairs {
+		keyLen := int(binary.LittleEndian.Uint32(sizes[8*i:]))
+		valueLen := int(binary.LittleEndian.Uint32(sizes[8*i+4:]))
+		if len(data) < keyLen+valueLen+2 {
+			return nil, false
+		}
+		pairs[i] = pair{string(data[:keyLen]), string(data[keyLen+1 : keyLen+1+valueLen])}
+		data = data[keyLen+valueLen+2:]
+	}
+	return pairs, true
+}
+
+func readString(mod api.Module, ptr, size uint32) (string, bool) {
+	data, ok := mod.Memory().Read(ptr, size)
+	return string(data), ok
+}
+
+func writeU32(mod api.Module, ptr, value uint32) uint32 {
+	if !mod.Memory().WriteUint32Le(ptr, value) {
+		return statusInvalidMemoryAccess
+	}
+	return statusOK
+}
+
+// returnBytes copies data to memory allocated by the application and stores its address and size
+func returnBytes(ctx context.Context, mod api.Module, data []byte, ptrPtr, sizePtr uint32) uint32 {
+	var ptr uint32
+	if len(data) > 0 {
+		alloc := mod.ExportedFunction("proxy_on_memory_allocate")
+		if alloc == nil {
+			alloc = mod.ExportedFunction("malloc")
+		}
+		if alloc == nil {
+			return statusInternalFailure
+		}
+		res, err := alloc.Call(ctx, uint64(len(data)))
+		if err != nil || len(res) == 0 {
+			return statusInternalFailure
+		}
+		ptr = api.DecodeU32(res[0])
+		if !mod.Memory().Write(ptr, data) {
+			return statusInvalidMemoryAccess
+		}
+	}
+	if status := writeU32(mod, ptrPtr, ptr); status != statusOK {
+		return status
+	}
+	return writeU32(mod, sizePtr, uint32(len(data)))
+}
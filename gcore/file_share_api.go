@@ -0,0 +1,117 @@
+package gcore
+
+import (
+	"fmt"
+	"net/http"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/file_share/v1/file_shares"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+// fileShareCreateOpts extends file_shares.CreateOpts with the snapshot to restore the file share from
+type fileShareCreateOpts struct {
+	file_shares.CreateOpts
+	SnapshotID string
+}
+
+func (opts fileShareCreateOpts) ToFileShareCreateMap() (map[string]interface{}, error) {
+	mp, err := opts.CreateOpts.ToFileShareCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.SnapshotID != "" {
+		mp["snapshot_id"] = opts.SnapshotID
+	}
+	return mp, nil
+}
+
+type fileShareSnapshot struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Size        int                     `json:"size"`
+	FileShareID string                  `json:"file_share_id"`
+	CreatedAt   gcorecloud.JSONRFC3339Z `json:"created_at"`
+}
+
+type fileShareSnapshotOpts struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func fileShareSnapshotsURL(client *gcorecloud.ServiceClient, fileShareID string, parts ...string) string {
+	return client.ServiceURL(append([]string{fileShareID, "snapshots"}, parts...)...)
+}
+
+// createFileShareSnapshot takes a snapshot of the file share
+func createFileShareSnapshot(client *gcorecloud.ServiceClient, fileShareID string, opts fileShareSnapshotOpts) (r tasks.Result) {
+	_, r.Err = client.Post(fileShareSnapshotsURL(client, fileShareID), opts, &r.Body, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusCreated},
+	})
+	return
+}
+
+// getFileShareSnapshot returns the snapshot of the file share
+func getFileShareSnapshot(client *gcorecloud.ServiceClient, fileShareID, snapshotID string) (*fileShareSnapshot, error) {
+	var snapshot fileShareSnapshot
+	if _, err := client.Get(fileShareSnapshotsURL(client, fileShareID, snapshotID), &snapshot, nil); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// updateFileShareSnapshot renames the snapshot or changes its description
+func updateFileShareSnapshot(client *gcorecloud.ServiceClient, fileShareID, snapshotID string, opts fileShareSnapshotOpts) error {
+	_, err := client.Patch(fileShareSnapshotsURL(client, fileShareID, snapshotID), opts, nil, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK},
+	})
+	return err
+}
+
+// deleteFileShareSnapshot deletes the snapshot of the file share
+func deleteFileShareSnapshot(client *gcorecloud.ServiceClient, fileShareID, snapshotID string) (r tasks.Result) {
+	_, r.Err = client.DeleteWithResponse(fileShareSnapshotsURL(client, fileShareID, snapshotID), &r.Body, nil)
+	return
+}
+
+// revertFileShare reverts the file share in place to its latest snapshot
+func revertFileShare(client *gcorecloud.ServiceClient, fileShareID, snapshotID string) (r tasks.Result) {
+	_, r.Err = client.Post(client.ServiceURL(fileShareID, "revert"), map[string]string{"snapshot_id": snapshotID}, &r.Body,
+		&gcorecloud.RequestOpts{OkCodes: []int{http.StatusOK, http.StatusCreated}})
+	return
+}
+
+type fileShareSnapshotTaskResult struct {
+	FileShareSnapshots []string `mapstructure:"file_share_snapshots"`
+}
+
+func extractFileShareSnapshotIDFromTask(task *tasks.Task) (string, error) {
+	var result fileShareSnapshotTaskResult
+	if err := gcorecloud.NativeMapToStruct(task.CreatedResources, &result); err != nil {
+		return "", fmt.Errorf("cannot decode file share snapshot information in task structure: %w", err)
+	}
+	if len(result.FileShareSnapshots) == 0 {
+		return "", fmt.Errorf("cannot decode file share snapshot information in task structure: no snapshot ID")
+	}
+	return result.FileShareSnapshots[0], nil
+}
+
+// findFileShareAccessRule returns the access rule of the file share, nil if there is no such rule
+func findFileShareAccessRule(client *gcorecloud.ServiceClient, fileShareID string, match func(rule file_shares.AccessRule) bool) (*file_shares.AccessRule, error) {
+	pages, err := file_shares.ListAccessRules(client, fileShareID).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	rules, err := file_shares.ExtractAccessRule(pages)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if match(rules[i]) {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
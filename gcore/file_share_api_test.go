@@ -0,0 +1,96 @@
+package gcore
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/file_share/v1/file_shares"
+)
+
+func TestFileShareCreateOptsSnapshot(t *testing.T) {
+	base := file_shares.CreateOpts{
+		Name:     "share",
+		Protocol: "NFS",
+		Size:     10,
+		TypeName: "vast",
+	}
+
+	mp, err := fileShareCreateOpts{CreateOpts: base}.ToFileShareCreateMap()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := mp["snapshot_id"]; ok {
+		t.Errorf("snapshot_id must not be sent without a snapshot: %v", mp)
+	}
+
+	mp, err = fileShareCreateOpts{CreateOpts: base, SnapshotID: "snap"}.ToFileShareCreateMap()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mp["snapshot_id"] != "snap" || mp["name"] != "share" {
+		t.Errorf("unexpected create body: %v", mp)
+	}
+}
+
+func TestFileShareAccessSet(t *testing.T) {
+	rules := fileShareAccessSet([]interface{}{
+		map[string]interface{}{"ip_address": "10.0.0.0/24", "access_mode": "rw"},
+		map[string]interface{}{"ip_address": "10.0.0.0/24", "access_mode": "ro"},
+		map[string]interface{}{"ip_address": "10.0.0.0/24", "access_mode": "rw"},
+	})
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %v", rules)
+	}
+	if rule := rules["10.0.0.0/24/ro"]; rule.IPAddress != "10.0.0.0/24" || rule.AccessMode != "ro" {
+		t.Errorf("unexpected rule: %v", rule)
+	}
+}
+
+func TestFileShareSnapshotAPI(t *testing.T) {
+	client := newAPIFixtureClient(t,
+		apiFixture{
+			method: http.MethodPost, path: "/fs/snapshots", status: http.StatusCreated,
+			request:  `{"name":"nightly","description":"before upgrade"}`,
+			response: `{"tasks":["t1"]}`,
+		},
+		apiFixture{
+			method: http.MethodGet, path: "/fs/snapshots/snap", status: http.StatusOK,
+			response: `{"id":"snap","name":"nightly","status":"available","size":10,"file_share_id":"fs",
+				"created_at":"2024-03-01T03:00:00+0000"}`,
+		},
+		apiFixture{
+			method: http.MethodPatch, path: "/fs/snapshots/snap", status: http.StatusOK,
+			request:  `{"name":"weekly"}`,
+			response: `{}`,
+		},
+		apiFixture{
+			method: http.MethodDelete, path: "/fs/snapshots/snap", status: http.StatusOK,
+			response: `{"tasks":["t2"]}`,
+		},
+		apiFixture{
+			method: http.MethodPost, path: "/fs/revert", status: http.StatusOK,
+			request:  `{"snapshot_id":"snap"}`,
+			response: `{"tasks":["t3"]}`,
+		},
+	)
+
+	results, err := createFileShareSnapshot(client, "fs", fileShareSnapshotOpts{Name: "nightly", Description: "before upgrade"}).Extract()
+	if err != nil || len(results.Tasks) != 1 || results.Tasks[0] != "t1" {
+		t.Errorf("createFileShareSnapshot = %+v, %v", results, err)
+	}
+	snapshot, err := getFileShareSnapshot(client, "fs", "snap")
+	if err != nil || snapshot.Status != "available" || snapshot.CreatedAt.IsZero() {
+		t.Errorf("getFileShareSnapshot = %+v, %v", snapshot, err)
+	}
+	if err := updateFileShareSnapshot(client, "fs", "snap", fileShareSnapshotOpts{Name: "weekly"}); err != nil {
+		t.Errorf("updateFileShareSnapshot: %v", err)
+	}
+	results, err = deleteFileShareSnapshot(client, "fs", "snap").Extract()
+	if err != nil || len(results.Tasks) != 1 || results.Tasks[0] != "t2" {
+		t.Errorf("deleteFileShareSnapshot = %+v, %v", results, err)
+	}
+	results, err = revertFileShare(client, "fs", "snap").Extract()
+	if err != nil || len(results.Tasks) != 1 || results.Tasks[0] != "t3" {
+		t.Errorf("revertFileShare = %+v, %v", results, err)
+	}
+}
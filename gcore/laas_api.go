@@ -0,0 +1,80 @@
+package gcore
+
+import (
+	"fmt"
+	"net/http"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
+)
+
+// laasTopic extends laas.Topic with the topic settings
+type laasTopic struct {
+	Name        string `json:"name"`
+	Partitions  int    `json:"partitions"`
+	RetentionMs int    `json:"retention_ms"`
+}
+
+// laasTopicCreateOpts extends laas.CreateTopicOpts with the topic settings, API defaults are used for unset ones
+type laasTopicCreateOpts struct {
+	laas.CreateTopicOpts
+	Partitions  int
+	RetentionMs int
+}
+
+func (opts laasTopicCreateOpts) ToTopicCreateMap() (map[string]interface{}, error) {
+	mp, err := opts.CreateTopicOpts.ToTopicCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Partitions != 0 {
+		mp["partitions"] = opts.Partitions
+	}
+	if opts.RetentionMs != 0 {
+		mp["retention_ms"] = opts.RetentionMs
+	}
+	return mp, nil
+}
+
+type laasTopicUpdateOpts struct {
+	Partitions  int `json:"partitions,omitempty"`
+	RetentionMs int `json:"retention_ms,omitempty"`
+}
+
+// updateLaaSTopic changes the topic settings, zero values are kept
+func updateLaaSTopic(client *gcorecloud.ServiceClient, name string, opts laasTopicUpdateOpts) error {
+	_, err := client.Patch(client.ServiceURL("topics", name), opts, nil, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK},
+	})
+	return err
+}
+
+// getLaaSTopic returns the topic with its settings, nil if there is no such topic
+func getLaaSTopic(client *gcorecloud.ServiceClient, name string) (*laasTopic, error) {
+	pages, err := laas.ListTopic(client).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	var topics []laasTopic
+	if err := laas.ExtractTopicsInto(pages, &topics); err != nil {
+		return nil, err
+	}
+	for i := range topics {
+		if topics[i].Name == name {
+			return &topics[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// regenerateLaaSUser generates a new password of the Kafka user of the namespace, the previous one stops working
+func regenerateLaaSUser(client *gcorecloud.ServiceClient) (*laas.User, error) {
+	user, err := laas.RegenerateUser(client).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate LaaS credentials: %w", err)
+	}
+	if user.Password == "" {
+		return nil, fmt.Errorf("cannot generate LaaS credentials: no password returned")
+	}
+	return user, nil
+}
@@ -0,0 +1,51 @@
+package gcore
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
+)
+
+func TestLaaSTopicCreateOptsToMap(t *testing.T) {
+	tests := []struct {
+		name string
+		opts laasTopicCreateOpts
+		want map[string]interface{}
+	}{
+		{
+			name: "defaults",
+			opts: laasTopicCreateOpts{CreateTopicOpts: laas.CreateTopicOpts{Name: "topic"}},
+			want: map[string]interface{}{"name": "topic"},
+		},
+		{
+			name: "settings",
+			opts: laasTopicCreateOpts{CreateTopicOpts: laas.CreateTopicOpts{Name: "topic"}, Partitions: 3, RetentionMs: -1},
+			want: map[string]interface{}{"name": "topic", "partitions": 3, "retention_ms": -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.ToTopicCreateMap()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateLaaSTopic(t *testing.T) {
+	client := newAPIFixtureClient(t, apiFixture{
+		method: http.MethodPatch, path: "/topics/events", status: http.StatusOK,
+		request:  `{"partitions":6}`,
+		response: `{}`,
+	})
+
+	if err := updateLaaSTopic(client, "events", laasTopicUpdateOpts{Partitions: 6}); err != nil {
+		t.Errorf("updateLaaSTopic: %v", err)
+	}
+}
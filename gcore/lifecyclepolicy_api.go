@@ -0,0 +1,158 @@
+package gcore
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/lifecyclepolicy/v1/lifecyclepolicy"
+)
+
+const lifecyclePolicyActionInstanceBackup = "instance_backup"
+
+var lifecyclePolicyActions = []string{lifecyclepolicy.PolicyActionVolumeSnapshot.String(), lifecyclePolicyActionInstanceBackup}
+
+// lifecyclePolicyCopyOpts copies the snapshots taken by the policy to another region
+type lifecyclePolicyCopyOpts struct {
+	RegionID    int `json:"region_id"`
+	MaxQuantity int `json:"max_quantity,omitempty"`
+}
+
+// lifecyclePolicyCreateOpts extends lifecyclepolicy.CreateOpts with instance backups, metadata based volume selection
+// and cross-region copy
+type lifecyclePolicyCreateOpts struct {
+	lifecyclepolicy.CreateOpts
+	InstanceIDs     []string
+	VolumeMetadata  map[string]string
+	CrossRegionCopy []lifecyclePolicyCopyOpts
+}
+
+func (opts lifecyclePolicyCreateOpts) toMap() (map[string]interface{}, error) {
+	// the SDK only knows about the volume_snapshot action
+	if err := gcorecloud.TranslateValidationError(gcorecloud.Validate.StructExcept(opts.CreateOpts, "Action")); err != nil {
+		return nil, err
+	}
+	if err := validateLifecyclePolicyAction(opts.Action.String()); err != nil {
+		return nil, err
+	}
+	mp, err := gcorecloud.BuildRequestBody(opts.CreateOpts, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.InstanceIDs) > 0 {
+		mp["instance_ids"] = opts.InstanceIDs
+	}
+	if len(opts.VolumeMetadata) > 0 {
+		mp["volume_metadata"] = opts.VolumeMetadata
+	}
+	if len(opts.CrossRegionCopy) > 0 {
+		mp["cross_region_copy"] = opts.CrossRegionCopy
+	}
+	return mp, nil
+}
+
+// lifecyclePolicyUpdateOpts extends lifecyclepolicy.UpdateOpts with volume metadata and cross-region copy,
+// which are only sent when not nil, empty values clear them
+type lifecyclePolicyUpdateOpts struct {
+	lifecyclepolicy.UpdateOpts
+	VolumeMetadata  map[string]string
+	CrossRegionCopy []lifecyclePolicyCopyOpts
+}
+
+func (opts lifecyclePolicyUpdateOpts) toMap() (map[string]interface{}, error) {
+	if err := gcorecloud.ValidateStruct(opts.UpdateOpts); err != nil {
+		return nil, err
+	}
+	mp, err := gcorecloud.BuildRequestBody(opts.UpdateOpts, "")
+	if err != nil {
+		return nil, err
+	}
+	if opts.VolumeMetadata != nil {
+		mp["volume_metadata"] = opts.VolumeMetadata
+	}
+	if opts.CrossRegionCopy != nil {
+		mp["cross_region_copy"] = opts.CrossRegionCopy
+	}
+	return mp, nil
+}
+
+// lifecyclePolicyExtra holds the policy fields not decoded by lifecyclepolicy.LifecyclePolicy
+type lifecyclePolicyExtra struct {
+	Instances []struct {
+		ID   string `json:"instance_id"`
+		Name string `json:"instance_name"`
+	} `json:"instances"`
+	VolumeMetadata  map[string]string         `json:"volume_metadata"`
+	CrossRegionCopy []lifecyclePolicyCopyOpts `json:"cross_region_copy"`
+}
+
+// lifecyclePolicySnapshot is a snapshot taken or copied by a lifecycle policy
+type lifecyclePolicySnapshot struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	VolumeID   string `json:"volume_id"`
+	InstanceID string `json:"instance_id"`
+	ScheduleID string `json:"schedule_id"`
+	RegionID   int    `json:"region_id"`
+	Size       int    `json:"size"`
+	Status     string `json:"status"`
+	CreatedAt  string `json:"created_at"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+func validateLifecyclePolicyAction(action string) error {
+	for _, a := range lifecyclePolicyActions {
+		if a == action {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid lifecycle policy action: %s", action)
+}
+
+// createLifecyclePolicy replaces lifecyclepolicy.Create to send lifecyclePolicyCreateOpts
+func createLifecyclePolicy(client *gcorecloud.ServiceClient, opts lifecyclePolicyCreateOpts) (r lifecyclepolicy.CreateResult) {
+	b, err := opts.toMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(client.ServiceURL(), b, &r.Body, nil)
+	return
+}
+
+// updateLifecyclePolicy replaces lifecyclepolicy.Update to send lifecyclePolicyUpdateOpts
+func updateLifecyclePolicy(client *gcorecloud.ServiceClient, id int, opts lifecyclePolicyUpdateOpts) (r lifecyclepolicy.UpdateResult) {
+	b, err := opts.toMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Patch(client.ServiceURL(strconv.Itoa(id)), b, &r.Body, nil)
+	return
+}
+
+// addLifecyclePolicyInstances adds instances to the backup schedules of the policy
+func addLifecyclePolicyInstances(client *gcorecloud.ServiceClient, id int, instanceIDs []string) error {
+	_, err := client.Put(client.ServiceURL(strconv.Itoa(id), "add_instances_to_policy"),
+		map[string][]string{"instance_ids": instanceIDs}, nil, &gcorecloud.RequestOpts{OkCodes: []int{http.StatusOK}})
+	return err
+}
+
+// removeLifecyclePolicyInstances removes instances from the policy
+func removeLifecyclePolicyInstances(client *gcorecloud.ServiceClient, id int, instanceIDs []string) error {
+	_, err := client.Put(client.ServiceURL(strconv.Itoa(id), "remove_instances_from_policy"),
+		map[string][]string{"instance_ids": instanceIDs}, nil, &gcorecloud.RequestOpts{OkCodes: []int{http.StatusOK}})
+	return err
+}
+
+// listLifecyclePolicySnapshots returns the snapshots taken by the policy, including copies in other regions
+func listLifecyclePolicySnapshots(client *gcorecloud.ServiceClient, id int) ([]lifecyclePolicySnapshot, error) {
+	var body struct {
+		Results []lifecyclePolicySnapshot `json:"results"`
+	}
+	if _, err := client.Get(client.ServiceURL(strconv.Itoa(id), "snapshots"), &body, nil); err != nil {
+		return nil, err
+	}
+	return body.Results, nil
+}
@@ -0,0 +1,125 @@
+package gcore
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/lifecyclepolicy/v1/lifecyclepolicy"
+)
+
+func TestLifecyclePolicyCreateOptsInstanceBackup(t *testing.T) {
+	opts := lifecyclePolicyCreateOpts{
+		CreateOpts: lifecyclepolicy.CreateOpts{
+			Name:   "instance backup",
+			Action: lifecyclepolicy.PolicyAction(lifecyclePolicyActionInstanceBackup),
+		},
+		InstanceIDs:     []string{"a9e2b7c4-39c8-4a3b-8b3e-6b1c5e0d2f11"},
+		CrossRegionCopy: []lifecyclePolicyCopyOpts{{RegionID: 76}},
+	}
+	mp, err := opts.toMap()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mp["action"] != lifecyclePolicyActionInstanceBackup {
+		t.Errorf("unexpected action: %v", mp["action"])
+	}
+	if !reflect.DeepEqual(mp["instance_ids"], opts.InstanceIDs) || !reflect.DeepEqual(mp["cross_region_copy"], opts.CrossRegionCopy) {
+		t.Errorf("unexpected create body: %v", mp)
+	}
+	if _, ok := mp["volume_metadata"]; ok {
+		t.Errorf("volume_metadata must not be sent when empty: %v", mp)
+	}
+
+	opts.Action = "image_backup"
+	if _, err := opts.toMap(); err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
+
+func TestLifecyclePolicyUpdateOptsClearsMetadata(t *testing.T) {
+	mp, err := lifecyclePolicyUpdateOpts{
+		UpdateOpts:      lifecyclepolicy.UpdateOpts{Name: "daily"},
+		VolumeMetadata:  map[string]string{},
+		CrossRegionCopy: []lifecyclePolicyCopyOpts{},
+	}.toMap()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md, ok := mp["volume_metadata"].(map[string]string); !ok || len(md) != 0 {
+		t.Errorf("expected empty volume_metadata, got %v", mp["volume_metadata"])
+	}
+	if _, ok := mp["cross_region_copy"]; !ok {
+		t.Errorf("expected cross_region_copy in update body: %v", mp)
+	}
+
+	// unchanged settings are not sent
+	mp, err = lifecyclePolicyUpdateOpts{UpdateOpts: lifecyclepolicy.UpdateOpts{Name: "daily"}}.toMap()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := mp["volume_metadata"]; ok {
+		t.Errorf("unexpected volume_metadata in update body: %v", mp)
+	}
+	if _, ok := mp["cross_region_copy"]; ok {
+		t.Errorf("unexpected cross_region_copy in update body: %v", mp)
+	}
+}
+
+func TestLifecyclePolicyAPI(t *testing.T) {
+	client := newAPIFixtureClient(t,
+		apiFixture{
+			method: http.MethodPost, path: "/", status: http.StatusCreated,
+			request:  `{"name":"backup","action":"instance_backup","instance_ids":["inst"],"cross_region_copy":[{"region_id":76}]}`,
+			response: `{"id":1,"name":"backup","action":"instance_backup","status":"active","schedules":[],"volumes":[]}`,
+		},
+		apiFixture{
+			method: http.MethodPatch, path: "/1", status: http.StatusOK,
+			request:  `{"name":"daily","volume_metadata":{"backup":"true"}}`,
+			response: `{"id":1,"name":"daily","action":"instance_backup","status":"active","schedules":[],"volumes":[]}`,
+		},
+		apiFixture{
+			method: http.MethodPut, path: "/1/add_instances_to_policy", status: http.StatusOK,
+			request:  `{"instance_ids":["a"]}`,
+			response: `{}`,
+		},
+		apiFixture{
+			method: http.MethodPut, path: "/1/remove_instances_from_policy", status: http.StatusOK,
+			request:  `{"instance_ids":["inst"]}`,
+			response: `{}`,
+		},
+		apiFixture{
+			method: http.MethodGet, path: "/1/snapshots", status: http.StatusOK,
+			response: `{"count":1,"results":[{"id":"snap","instance_id":"a","region_id":76,"status":"available"}]}`,
+		},
+	)
+
+	policy, err := createLifecyclePolicy(client, lifecyclePolicyCreateOpts{
+		CreateOpts: lifecyclepolicy.CreateOpts{
+			Name:   "backup",
+			Action: lifecyclepolicy.PolicyAction(lifecyclePolicyActionInstanceBackup),
+		},
+		InstanceIDs:     []string{"inst"},
+		CrossRegionCopy: []lifecyclePolicyCopyOpts{{RegionID: 76}},
+	}).Extract()
+	if err != nil || policy.ID != 1 {
+		t.Errorf("createLifecyclePolicy = %+v, %v", policy, err)
+	}
+	policy, err = updateLifecyclePolicy(client, 1, lifecyclePolicyUpdateOpts{
+		UpdateOpts:     lifecyclepolicy.UpdateOpts{Name: "daily"},
+		VolumeMetadata: map[string]string{"backup": "true"},
+	}).Extract()
+	if err != nil || policy.Name != "daily" {
+		t.Errorf("updateLifecyclePolicy = %+v, %v", policy, err)
+	}
+	if err := addLifecyclePolicyInstances(client, 1, []string{"a"}); err != nil {
+		t.Errorf("addLifecyclePolicyInstances: %v", err)
+	}
+	if err := removeLifecyclePolicyInstances(client, 1, []string{"inst"}); err != nil {
+		t.Errorf("removeLifecyclePolicyInstances: %v", err)
+	}
+	snapshots, err := listLifecyclePolicySnapshots(client, 1)
+	if err != nil || len(snapshots) != 1 || snapshots[0].RegionID != 76 {
+		t.Errorf("listLifecyclePolicySnapshots = %+v, %v", snapshots, err)
+	}
+}
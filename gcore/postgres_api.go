@@ -0,0 +1,395 @@
+package gcore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/dbaas/postgres/v1/clusters"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+type postgresBackupOpts struct {
+	Schedule        string `json:"schedule"`
+	RetentionDays   int    `json:"retention_days"`
+	StorageLocation string `json:"storage_location,omitempty"`
+}
+
+type postgresRestoreOpts struct {
+	ClusterName string `json:"cluster_name"`
+	BackupID    string `json:"backup_id,omitempty"`
+	PointInTime string `json:"point_in_time,omitempty"`
+}
+
+// postgresNetworkOpts replaces clusters.NetworkOpts, which only allows public networks
+type postgresNetworkOpts struct {
+	ACL         []string `json:"acl"`
+	NetworkType string   `json:"network_type" validate:"required,oneof=public private"`
+	NetworkID   string   `json:"network_id,omitempty" validate:"required_if=NetworkType private"`
+	SubnetID    string   `json:"subnet_id,omitempty" validate:"required_if=NetworkType private"`
+}
+
+type postgresReadReplicasOpts struct {
+	Count  int                  `json:"count" validate:"gte=0"`
+	Flavor *clusters.FlavorOpts `json:"flavor,omitempty" validate:"omitempty"`
+}
+
+// postgresClusterCreateOpts extends clusters.CreateOpts with backup configuration, the restore source,
+// read replicas and private networks. CreateOpts.Network is ignored, Network is used instead.
+type postgresClusterCreateOpts struct {
+	clusters.CreateOpts
+	Network      *postgresNetworkOpts
+	ReadReplicas *postgresReadReplicasOpts
+	Backup       *postgresBackupOpts
+	RestoreFrom  *postgresRestoreOpts
+}
+
+func (opts postgresClusterCreateOpts) ToCreateMap() (map[string]interface{}, error) {
+	if err := gcorecloud.TranslateValidationError(gcorecloud.Validate.StructExcept(opts.CreateOpts, "Network")); err != nil {
+		return nil, err
+	}
+	if err := postgresValidateOpts(opts.Network, opts.ReadReplicas); err != nil {
+		return nil, err
+	}
+	mp, err := gcorecloud.BuildRequestBody(opts.CreateOpts, "")
+	if err != nil {
+		return nil, err
+	}
+	mp["network"] = opts.Network
+	if opts.ReadReplicas != nil {
+		mp["read_replicas"] = opts.ReadReplicas
+	}
+	if opts.Backup != nil {
+		mp["backup"] = opts.Backup
+	}
+	if opts.RestoreFrom != nil {
+		mp["restore_from"] = opts.RestoreFrom
+	}
+	return mp, nil
+}
+
+// postgresClusterUpdateOpts extends clusters.UpdateOpts with backup configuration, read replicas and private networks.
+// UpdateOpts.Network is ignored, Network is used instead.
+type postgresClusterUpdateOpts struct {
+	clusters.UpdateOpts
+	Network      *postgresNetworkOpts
+	ReadReplicas *postgresReadReplicasOpts
+	Backup       *postgresBackupOpts
+}
+
+func (opts postgresClusterUpdateOpts) ToUpdateMap() (map[string]interface{}, error) {
+	opts.UpdateOpts.Network = nil
+	if err := opts.UpdateOpts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := postgresValidateOpts(opts.Network, opts.ReadReplicas); err != nil {
+		return nil, err
+	}
+	mp, err := gcorecloud.BuildRequestBody(opts.UpdateOpts, "")
+	if err != nil {
+		return nil, err
+	}
+	if opts.Network != nil {
+		mp["network"] = opts.Network
+	}
+	if opts.ReadReplicas != nil {
+		mp["read_replicas"] = opts.ReadReplicas
+	}
+	if opts.Backup != nil {
+		mp["backup"] = opts.Backup
+	}
+	if len(mp) == 0 {
+		return nil, errors.New("empty UpdateOpts")
+	}
+	return mp, nil
+}
+
+func postgresValidateOpts(network *postgresNetworkOpts, replicas *postgresReadReplicasOpts) error {
+	if network != nil {
+		if err := gcorecloud.ValidateStruct(network); err != nil {
+			return err
+		}
+	}
+	if replicas != nil {
+		return gcorecloud.ValidateStruct(replicas)
+	}
+	return nil
+}
+
+// postgresClusterExtra holds cluster fields missing in clusters.PostgresSQLCluster
+type postgresClusterExtra struct {
+	Backup  *postgresBackupOpts `json:"backup"`
+	Network struct {
+		NetworkID string `json:"network_id"`
+		SubnetID  string `json:"subnet_id"`
+	} `json:"network"`
+	PGServerConfiguration struct {
+		// PendingRestart lists the changed parameters that take effect after a cluster restart
+		PendingRestart []string `json:"pending_restart"`
+	} `json:"pg_server_configuration"`
+	ReadReplicas *struct {
+		Count            int              `json:"count"`
+		Flavor           *clusters.Flavor `json:"flavor"`
+		ConnectionString string           `json:"connection_string"`
+		Host             string           `json:"host"`
+	} `json:"read_replicas"`
+}
+
+type postgresBackup struct {
+	ID                  string `json:"id"`
+	Type                string `json:"type"`
+	Status              string `json:"status"`
+	SizeBytes           int64  `json:"size_bytes"`
+	StorageLocation     string `json:"storage_location"`
+	StartedAt           string `json:"started_at"`
+	FinishedAt          string `json:"finished_at"`
+	RecoveryWindowStart string `json:"recovery_window_start"`
+	RecoveryWindowEnd   string `json:"recovery_window_end"`
+}
+
+// listPostgresBackups returns backups of the cluster, ordered by the API from the oldest one
+func listPostgresBackups(client *gcorecloud.ServiceClient, clusterName string) ([]postgresBackup, error) {
+	var body struct {
+		Results []postgresBackup `json:"results"`
+	}
+	if _, err := client.Get(client.ServiceURL(clusterName, "backups"), &body, nil); err != nil {
+		return nil, err
+	}
+	return body.Results, nil
+}
+
+type postgresUserCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func postgresUserCredentialsURL(client *gcorecloud.ServiceClient, clusterName, userName string) string {
+	return client.ServiceURL(clusterName, "users", userName, "credentials")
+}
+
+// getPostgresUserCredentials reveals the user password, the user is then marked with is_secret_revealed
+func getPostgresUserCredentials(client *gcorecloud.ServiceClient, clusterName, userName string) (*postgresUserCredentials, error) {
+	var creds postgresUserCredentials
+	if _, err := client.Get(postgresUserCredentialsURL(client, clusterName, userName), &creds, nil); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
+// generatePostgresUserPassword replaces the user password with a generated one
+func generatePostgresUserPassword(client *gcorecloud.ServiceClient, clusterName, userName string) (*postgresUserCredentials, error) {
+	var creds postgresUserCredentials
+	_, err := client.Post(postgresUserCredentialsURL(client, clusterName, userName), nil, &creds, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusCreated},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
+// setPostgresUserPassword replaces the user password with the given one
+func setPostgresUserPassword(client *gcorecloud.ServiceClient, clusterName, userName, password string) error {
+	_, err := client.Put(postgresUserCredentialsURL(client, clusterName, userName), postgresUserCredentials{
+		Username: userName,
+		Password: password,
+	}, nil, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusNoContent},
+	})
+	return err
+}
+
+// postgresConnectionURI returns the cluster connection string with the user credentials and database
+func postgresConnectionURI(connectionString, host, userName, password, database string) string {
+	uri, err := url.Parse(connectionString)
+	if err != nil || uri.Host == "" {
+		uri = &url.URL{Scheme: "postgresql", Host: host}
+	}
+	uri.User = url.UserPassword(userName, password)
+	if database != "" {
+		uri.Path = "/" + database
+	}
+	return uri.String()
+}
+
+const postgresConfigurationPoint = "dbaas/postgres/configuration"
+
+// postgresConfiguration is the catalog of versions, flavors and parameters available in the region
+type postgresConfiguration struct {
+	Versions   []string            `json:"pg_versions"`
+	Flavors    []clusters.Flavor   `json:"flavors"`
+	Parameters []postgresParameter `json:"parameters"`
+}
+
+type postgresParameter struct {
+	Name string `json:"name"`
+	// Type is one of bool, integer, real, enum and string
+	Type string `json:"type"`
+	// Unit is the base unit of integer and real values, e.g. 8kB or ms
+	Unit            string   `json:"unit"`
+	Min             *float64 `json:"min"`
+	Max             *float64 `json:"max"`
+	AllowedValues   []string `json:"allowed_values"`
+	RestartRequired bool     `json:"restart_required"`
+	Versions        []string `json:"versions"`
+}
+
+// getPostgresConfiguration returns the catalog of the region
+func getPostgresConfiguration(client *gcorecloud.ServiceClient) (*postgresConfiguration, error) {
+	var configuration postgresConfiguration
+	if _, err := client.Get(client.ServiceURL(), &configuration, nil); err != nil {
+		return nil, err
+	}
+	return &configuration, nil
+}
+
+func (c *postgresConfiguration) parameter(name, version string) *postgresParameter {
+	for i := range c.Parameters {
+		p := &c.Parameters[i]
+		if p.Name == name && (len(p.Versions) == 0 || slices.Contains(p.Versions, version)) {
+			return p
+		}
+	}
+	return nil
+}
+
+type postgresUpgradeCheck struct {
+	Compatible bool     `json:"compatible"`
+	Issues     []string `json:"issues"`
+}
+
+// checkPostgresUpgrade checks if the cluster can be upgraded to the major version in place
+func checkPostgresUpgrade(client *gcorecloud.ServiceClient, clusterName, version string) (*postgresUpgradeCheck, error) {
+	var check postgresUpgradeCheck
+	_, err := client.Post(client.ServiceURL(clusterName, "upgrade", "check"), map[string]string{"version": version}, &check,
+		&gcorecloud.RequestOpts{OkCodes: []int{http.StatusOK}})
+	if err != nil {
+		return nil, err
+	}
+	return &check, nil
+}
+
+// upgradePostgresCluster starts an in-place major version upgrade of the cluster
+func upgradePostgresCluster(client *gcorecloud.ServiceClient, clusterName, version string) (r tasks.Result) {
+	_, r.Err = client.Post(client.ServiceURL(clusterName, "upgrade"), map[string]string{"version": version}, &r.Body,
+		&gcorecloud.RequestOpts{OkCodes: []int{http.StatusOK, http.StatusCreated}})
+	return
+}
+
+var postgresParameterValueRe = regexp.MustCompile(`^\s*(-?[0-9]+(?:\.[0-9]+)?)\s*([a-zA-Z]*)\s*$`)
+
+// validatePostgresParameterValue checks the value type, unit and range, values with a unit are converted to the base unit
+func validatePostgresParameterValue(p *postgresParameter, value string) error {
+	switch p.Type {
+	case "bool":
+		switch strings.ToLower(value) {
+		case "on", "off", "true", "false", "yes", "no", "1", "0":
+			return nil
+		}
+		return fmt.Errorf("parameter %s must be a boolean (on/off), got %q", p.Name, value)
+	case "enum":
+		if !slices.Contains(p.AllowedValues, value) {
+			return fmt.Errorf("parameter %s must be one of %s, got %q", p.Name, strings.Join(p.AllowedValues, ", "), value)
+		}
+		return nil
+	case "integer", "real":
+	default:
+		return nil
+	}
+
+	match := postgresParameterValueRe.FindStringSubmatch(value)
+	if match == nil {
+		return fmt.Errorf("parameter %s must be a number, got %q", p.Name, value)
+	}
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return fmt.Errorf("parameter %s must be a number, got %q", p.Name, value)
+	}
+	if p.Type == "integer" && match[2] == "" && strings.Contains(match[1], ".") {
+		return fmt.Errorf("parameter %s must be an integer, got %q", p.Name, value)
+	}
+	if match[2] != "" {
+		baseFactor, baseMemory, ok := postgresUnitFactor(p.Unit)
+		if !ok {
+			return fmt.Errorf("parameter %s has no unit, got %q", p.Name, value)
+		}
+		factor, memory, ok := postgresUnitFactor(match[2])
+		if !ok || memory != baseMemory {
+			return fmt.Errorf("parameter %s has invalid unit %q, the base unit is %s", p.Name, match[2], p.Unit)
+		}
+		number = number * factor / baseFactor
+	}
+	if (p.Min != nil && number < *p.Min) || (p.Max != nil && number > *p.Max) {
+		return fmt.Errorf("parameter %s must be between %s and %s %s, got %q", p.Name,
+			postgresParameterBound(p.Min), postgresParameterBound(p.Max), p.Unit, value)
+	}
+	return nil
+}
+
+func postgresParameterBound(bound *float64) string {
+	if bound == nil {
+		return "unlimited"
+	}
+	return strconv.FormatFloat(*bound, 'f', -1, 64)
+}
+
+// postgresUnitFactor returns the size of a unit like kB, 8kB or min in bytes or milliseconds
+func postgresUnitFactor(unit string) (factor float64, memory bool, ok bool) {
+	memoryUnits := map[string]float64{"B": 1, "kB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30, "TB": 1 << 40}
+	timeUnits := map[string]float64{"us": 0.001, "ms": 1, "s": 1000, "min": 60000, "h": 3600000, "d": 86400000}
+
+	multiplier := 1.0
+	if i := strings.IndexFunc(unit, func(r rune) bool { return r < '0' || r > '9' }); i > 0 {
+		multiplier, _ = strconv.ParseFloat(unit[:i], 64)
+		unit = unit[i:]
+	}
+	if f, found := memoryUnits[unit]; found {
+		return multiplier * f, true, true
+	}
+	if f, found := timeUnits[unit]; found {
+		return multiplier * f, false, true
+	}
+	return 0, false, false
+}
+
+// parsePGConf converts pg_conf in "key=value" format to a map, ignoring empty lines and comments.
+// A malformed line without '=' is kept as a key with empty value, so that its changes are detected.
+func parsePGConf(s string) map[string]string {
+	m := make(map[string]string)
+	sc := bufio.NewScanner(strings.NewReader(s))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			m[line] = ""
+			continue
+		}
+		m[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return m
+}
+
+// renderPGConf converts the map back to pg_conf, sorted by key
+func renderPGConf(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k + "=" + m[k] + "\n")
+	}
+	return b.String()
+}
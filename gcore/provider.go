@@ -17,6 +17,8 @@ import (
 	gcdnProvider "github.com/G-Core/gcorelabscdn-go/gcore/provider"
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform/version"
@@ -160,15 +162,21 @@ func Provider() *schema.Provider {
 			"gcore_securitygroup":                 resourceSecurityGroup(),
 			"gcore_baremetal":                     resourceBmInstance(),
 			"gcore_snapshot":                      resourceSnapshot(),
+			"gcore_snapshot_copy":                 resourceSnapshotCopy(),
 			"gcore_servergroup":                   resourceServerGroup(),
 			"gcore_k8sv2":                         resourceK8sV2(),
 			"gcore_secret":                        resourceSecret(),
 			"gcore_laas_topic":                    resourceLaaSTopic(),
+			"gcore_laas_credentials":              resourceLaaSCredentials(),
+			"gcore_laas_status":                   resourceLaaSStatus(),
 			"gcore_faas_namespace":                resourceFaaSNamespace(),
 			"gcore_faas_function":                 resourceFaaSFunction(),
 			"gcore_faas_key":                      resourceFaaSKey(),
+			"gcore_faas_trigger":                  resourceFaaSTrigger(),
 			"gcore_storage_s3":                    resourceStorageS3(),
 			"gcore_storage_s3_bucket":             resourceStorageS3Bucket(),
+			"gcore_storage_s3_credentials":        resourceStorageS3Credentials(),
+			"gcore_storage_s3_object":             resourceStorageS3Object(),
 			DNSZoneResource:                       resourceDNSZone(),
 			DNSZoneRecordResource:                 resourceDNSZoneRecord(),
 			DNSNetworkMappingResource:             resourceDNSNetworkMapping(),
@@ -206,8 +214,13 @@ func Provider() *schema.Provider {
 			"gcore_waap_custom_page_set":          resourceWaapCustomPageSet(),
 			"gcore_waap_policy":                   resourceWaapPolicy(),
 			"gcore_waap_firewall_rule":            resourceWaapFirewallRule(),
+			"gcore_waap_rule_set":                 resourceWaapRuleSet(),
+			"gcore_waap_rule_set_attachment":      resourceWaapRuleSetAttachment(),
 			"gcore_file_share":                    resourceFileShare(),
+			"gcore_file_share_snapshot":           resourceFileShareSnapshot(),
+			"gcore_file_share_access_rule":        resourceFileShareAccessRule(),
 			"gcore_postgres_cluster":              resourcePostgresCluster(),
+			"gcore_postgres_user":                 resourcePostgresUser(),
 			"gcore_port_allowed_address_pairs":    resourcePortAllowedAddressPairs(),
 			"gcore_port_security":                 resourcePortSecurity(),
 		},
@@ -218,6 +231,7 @@ func Provider() *schema.Provider {
 			"gcore_securitygroup":              dataSourceSecurityGroup(),
 			"gcore_image":                      dataSourceImage(),
 			"gcore_volume":                     dataSourceVolume(),
+			"gcore_lifecyclepolicy_snapshots":  dataSourceLifecyclePolicySnapshots(),
 			"gcore_network":                    dataSourceNetwork(),
 			"gcore_subnet":                     dataSourceSubnet(),
 			"gcore_router":                     dataSourceRouter(),
@@ -229,8 +243,12 @@ func Provider() *schema.Provider {
 			"gcore_floatingip":                 dataSourceFloatingIP(),
 			"gcore_storage_s3":                 dataSourceStorageS3(),
 			"gcore_storage_s3_bucket":          dataSourceStorageS3Bucket(),
+			"gcore_storage_s3_objects":         dataSourceStorageS3Objects(),
+			"gcore_storage_locations":          dataSourceStorageLocations(),
 			"gcore_storage_sftp":               dataSourceStorageSFTP(),
 			"gcore_storage_sftp_key":           dataSourceStorageSFTPKey(),
+			"gcore_storages":                   dataSourceStorages(),
+			"gcore_storage_usage":              dataSourceStorageUsage(),
 			"gcore_reservedfixedip":            dataSourceReservedFixedIP(),
 			"gcore_servergroup":                dataSourceServerGroup(),
 			"gcore_k8sv2":                      dataSourceK8sV2(),
@@ -241,6 +259,7 @@ func Provider() *schema.Provider {
 			"gcore_faas_namespace":             dataSourceFaaSNamespace(),
 			"gcore_faas_key":                   dataSourceFaaSKey(),
 			"gcore_faas_function":              dataSourceFaaSFunction(),
+			"gcore_faas_last_invocation":       dataSourceFaaSLastInvocation(),
 			"gcore_ddos_profile_template":      dataSourceDDoSProfileTemplate(),
 			"gcore_cdn_shielding_location":     dataOriginShieldingLocation(),
 			"gcore_cdn_preset":                 dataPreset(),
@@ -249,8 +268,18 @@ func Provider() *schema.Provider {
 			"gcore_waap_security_insight_type": dataWaapSecurityInsightType(),
 			"gcore_waap_domain_policy":         dataWaapDomainPolicy(),
 			"gcore_waap_tag":                   dataWaapTag(),
+			"gcore_waap_custom_rule_cel":       dataWaapCustomRuleCEL(),
+			"gcore_fastedge_app":               dataSourceFastEdgeApp(),
+			"gcore_fastedge_apps":              dataSourceFastEdgeApps(),
+			"gcore_fastedge_template":          dataSourceFastEdgeTemplate(),
+			"gcore_fastedge_binary":            dataSourceFastEdgeBinary(),
+			"gcore_fastedge_secret":            dataSourceFastEdgeSecret(),
+			"gcore_fastedge_app_check":         dataSourceFastEdgeAppCheck(),
 			"gcore_file_share":                 dataSourceFileShare(),
 			"gcore_postgres_cluster":           dataSourcePostgresCluster(),
+			"gcore_postgres_backups":           dataSourcePostgresBackups(),
+			"gcore_postgres_user":              dataSourcePostgresUser(),
+			"gcore_postgres_configuration":     dataSourcePostgresConfiguration(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -370,6 +399,17 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 			storageSDK.WithPermanentTokenAuth(func() string { return permanentToken }),
 			storageSDK.WithUserAgent(userAgent),
 		)
+		config.StorageAPI = newStorageAPI(stHost, stPath,
+			runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+				auth := "Bearer " + provider.AccessToken()
+				if permanentToken != "" {
+					auth = "APIKey " + permanentToken
+				}
+				if err := r.SetHeaderParam("Authorization", auth); err != nil {
+					return err
+				}
+				return r.SetHeaderParam("User-Agent", userAgent)
+			}))
 	}
 	if dnsAPI != "" {
 		baseUrl, err := url.Parse(dnsAPI)
@@ -19,13 +19,17 @@ const (
 	faaSFunctionDeleteTimeout = 2400
 )
 
+var faaSFunctionSources = []string{"code_text", "source_dir", "source_archive"}
+
 func resourceFaaSFunction() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceFaaSFunctionCreate,
 		ReadContext:   resourceFaaSFunctionRead,
 		UpdateContext: resourceFaaSFunctionUpdate,
 		DeleteContext: resourceFaaSFunctionDelete,
-		Description:   "Represent FaaS function",
+		CustomizeDiff: resourceFaaSFunctionCustomizeDiff,
+		Description: "Represent FaaS function. The code is set inline with `code_text`, " +
+			"or packaged from a local directory or zip archive and uploaded again when its content changes",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, nsName, fName, err := ImportStringParserExtended(d.Id())
@@ -111,8 +115,26 @@ func resourceFaaSFunction() *schema.Resource {
 				ForceNew: true,
 			},
 			"code_text": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: faaSFunctionSources,
+			},
+			"source_dir": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: faaSFunctionSources,
+				Description:  "Path to a local directory with the function code, zipped by the provider",
+			},
+			"source_archive": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: faaSFunctionSources,
+				Description:  "Path to a local zip archive with the function code",
+			},
+			"source_hash": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the packaged function code, used to detect changes of `source_dir` or `source_archive` content",
 			},
 			"timeout": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -239,7 +261,12 @@ func resourceFaaSFunctionCreate(ctx context.Context, d *schema.ResourceData, m i
 		opts.Keys = keys
 	}
 
-	results, err := faas.CreateFunction(client, nsName, opts).Extract()
+	codeArchive, err := loadFaaSFunctionSource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results, err := faas.CreateFunction(client, nsName, faasFunctionCreateOpts{CreateFunctionOpts: opts, CodeArchive: codeArchive}).Extract()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -257,9 +284,20 @@ func resourceFaaSFunctionCreate(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	d.SetId(funcID(fName, nsName))
+	if codeArchive != nil {
+		d.Set("source_hash", sha256Checksum(codeArchive))
+	}
 
 	resourceFaaSFunctionRead(ctx, d, m)
 
+	function, err := faas.GetFunction(client, nsName, fName).Extract()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if buildDiags := faaSBuildDiagnostics(function, diag.Error); buildDiags.HasError() {
+		return buildDiags
+	}
+
 	log.Printf("[DEBUG] Finish FaaS function creating (%s)", fName)
 	return diags
 }
@@ -285,6 +323,12 @@ func resourceFaaSFunctionRead(ctx context.Context, d *schema.ResourceData, m int
 	if err := faaSSetState(d, function); err != nil {
 		diag.FromErr(err)
 	}
+	if d.Get("source_dir").(string) != "" || d.Get("source_archive").(string) != "" {
+		// packaged code is tracked by source_hash
+		d.Set("code_text", "")
+	}
+	// failed builds of a previous apply show up during the plan refresh
+	diags = append(diags, faaSBuildDiagnostics(function, diag.Warning)...)
 
 	log.Println("[DEBUG] Finish FaaS function reading")
 	return diags
@@ -304,7 +348,7 @@ func resourceFaaSFunctionUpdate(ctx context.Context, d *schema.ResourceData, m i
 	nsName := d.Get("namespace").(string)
 
 	var needUpdate bool
-	opts := faas.UpdateFunctionOpts{}
+	opts := faasFunctionUpdateOpts{}
 	if d.HasChange("envs") {
 		envsRaw := d.Get("envs").(map[string]interface{})
 		envs := make(map[string]string, len(envsRaw))
@@ -321,8 +365,13 @@ func resourceFaaSFunctionUpdate(ctx context.Context, d *schema.ResourceData, m i
 		needUpdate = true
 	}
 
-	if d.HasChange("code_text") {
+	if d.HasChanges(append(faaSFunctionSources, "source_hash")...) {
+		codeArchive, err := loadFaaSFunctionSource(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		opts.CodeText = d.Get("code_text").(string)
+		opts.CodeArchive = codeArchive
 		needUpdate = true
 	}
 
@@ -391,6 +440,19 @@ func resourceFaaSFunctionUpdate(ctx context.Context, d *schema.ResourceData, m i
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		if opts.CodeArchive != nil {
+			d.Set("source_hash", sha256Checksum(opts.CodeArchive))
+		}
+		function, err := faas.GetFunction(client, nsName, fName).Extract()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if buildDiags := faaSBuildDiagnostics(function, diag.Error); buildDiags.HasError() {
+			// keep the previous state so that the next apply uploads the code again
+			d.Partial(true)
+			return buildDiags
+		}
 	}
 
 	log.Println("[DEBUG] Finish FaaS function updating")
@@ -440,6 +502,27 @@ func resourceFaaSFunctionDelete(ctx context.Context, d *schema.ResourceData, m i
 	return diags
 }
 
+// calculate the packaged code SHA-256 to detect source_dir and source_archive content change
+func resourceFaaSFunctionCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range faaSFunctionSources {
+		if !diff.NewValueKnown(key) {
+			return diff.SetNewComputed("source_hash")
+		}
+	}
+	codeArchive, err := loadFaaSFunctionSource(diff)
+	if err != nil {
+		return err
+	}
+	var checksum string
+	if codeArchive != nil {
+		checksum = sha256Checksum(codeArchive)
+	}
+	if checksum == diff.Get("source_hash").(string) {
+		return nil
+	}
+	return diff.SetNew("source_hash", checksum)
+}
+
 func funcID(fName, nsName string) string {
 	return fmt.Sprintf("%s_%s", fName, nsName)
 }
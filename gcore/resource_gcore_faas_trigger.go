@@ -0,0 +1,377 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const faaSTriggerDefaultTimezone = "UTC"
+
+// faaSCronFieldRegexps match the fields of a cron schedule, month and day of week also accept names like JAN or MON-FRI
+var faaSCronFieldRegexps = []*regexp.Regexp{
+	regexp.MustCompile(`^[0-9*/,\-]+$`),
+	regexp.MustCompile(`^[0-9*/,\-]+$`),
+	regexp.MustCompile(`^[0-9*/,\-]+$`),
+	regexp.MustCompile(`^(?i:[0-9*/,\-]|JAN|FEB|MAR|APR|MAY|JUN|JUL|AUG|SEP|OCT|NOV|DEC)+$`),
+	regexp.MustCompile(`^(?i:[0-9*/,\-]|SUN|MON|TUE|WED|THU|FRI|SAT)+$`),
+}
+
+func resourceFaaSTrigger() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFaaSTriggerCreate,
+		ReadContext:   resourceFaaSTriggerRead,
+		UpdateContext: resourceFaaSTriggerUpdate,
+		DeleteContext: resourceFaaSTriggerDelete,
+		CustomizeDiff: resourceFaaSTriggerCustomizeDiff,
+		Description:   "Represent FaaS function trigger, invoking the function on a cron schedule or on an HTTP route",
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				parts := strings.Split(d.Id(), ":")
+				if len(parts) != 5 {
+					return nil, fmt.Errorf("Failed import: wrong input id: %s", d.Id())
+				}
+				projectID, regionID, nsName, fName, err := ImportStringParserExtended(strings.Join(parts[:4], ":"))
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("namespace", nsName)
+				d.Set("function", fName)
+				d.Set("name", parts[4])
+				d.SetId(triggerID(parts[4], fName, nsName))
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"namespace": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Namespace of the function",
+			},
+			"function": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the invoked function",
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("Trigger type, one of: '%s', '%s'", faaSTriggerTypeCron, faaSTriggerTypeHTTP),
+				ValidateFunc: validation.StringInSlice([]string{faaSTriggerTypeCron, faaSTriggerTypeHTTP}, false),
+			},
+			"schedule": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Cron expression with 5 fields (minute, hour, day of month, month, day of week), month and day of week also accept names like JAN or MON-FRI. Required for 'cron' triggers",
+				ValidateFunc: validateFaaSCronSchedule,
+			},
+			"timezone": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Timezone of the schedule, for 'cron' triggers. Defaults to " + faaSTriggerDefaultTimezone,
+				ValidateFunc: func(val interface{}, key string) ([]string, []error) {
+					if _, err := time.LoadLocation(val.(string)); err != nil {
+						return nil, []error{fmt.Errorf("%q must be a valid timezone, got: %s", key, val)}
+					}
+					return nil, nil
+				},
+			},
+			"path": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Route path starting with '/', required for 'http' triggers",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/`), "must start with '/'"),
+			},
+			"methods": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "HTTP methods of the route, all methods are accepted if not set, for 'http' triggers",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}, false),
+				},
+			},
+			"enable_api_key": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Require one of `keys` to call the route, for 'http' triggers",
+			},
+			"keys": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Names of the FaaS API keys (gcore_faas_key) allowed to call the route",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"disabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Set to true to pause the trigger",
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"endpoint": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the route, for 'http' triggers",
+			},
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// check the settings match the trigger type
+func resourceFaaSTriggerCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	config := diff.GetRawConfig()
+	timezoneUnset := config.IsNull() || config.GetAttr("timezone").IsNull()
+	switch diff.Get("type").(string) {
+	case faaSTriggerTypeCron:
+		if diff.NewValueKnown("schedule") && diff.Get("schedule").(string) == "" {
+			return fmt.Errorf("schedule is required for '%s' triggers", faaSTriggerTypeCron)
+		}
+		for _, key := range []string{"path", "methods", "enable_api_key", "keys"} {
+			if _, ok := diff.GetOk(key); ok {
+				return fmt.Errorf("%s can only be set for '%s' triggers", key, faaSTriggerTypeHTTP)
+			}
+		}
+		if timezoneUnset && diff.Get("timezone").(string) != faaSTriggerDefaultTimezone {
+			return diff.SetNew("timezone", faaSTriggerDefaultTimezone)
+		}
+	case faaSTriggerTypeHTTP:
+		if diff.NewValueKnown("path") && diff.Get("path").(string) == "" {
+			return fmt.Errorf("path is required for '%s' triggers", faaSTriggerTypeHTTP)
+		}
+		if _, ok := diff.GetOk("schedule"); ok {
+			return fmt.Errorf("schedule can only be set for '%s' triggers", faaSTriggerTypeCron)
+		}
+		if !timezoneUnset {
+			return fmt.Errorf("timezone can only be set for '%s' triggers", faaSTriggerTypeCron)
+		}
+	}
+	return nil
+}
+
+func resourceFaaSTriggerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start FaaS trigger creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, faasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	fName := d.Get("function").(string)
+	nsName := d.Get("namespace").(string)
+	opts := faaSTriggerOptsFromResource(d)
+	opts.Name = name
+	opts.Type = d.Get("type").(string)
+
+	if _, err := createFaaSTrigger(client, nsName, fName, opts); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(triggerID(name, fName, nsName))
+
+	log.Printf("[DEBUG] Finish FaaS trigger creating (%s)", d.Id())
+	return resourceFaaSTriggerRead(ctx, d, m)
+}
+
+func resourceFaaSTriggerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start FaaS trigger reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, faasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	trigger, err := getFaaSTrigger(client, d.Get("namespace").(string), d.Get("function").(string), d.Get("name").(string))
+	if err != nil {
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			log.Printf("[WARN] Removing trigger %s because resource doesn't exist anymore", d.Id())
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	d.Set("type", trigger.Type)
+	d.Set("schedule", trigger.Schedule)
+	if trigger.Type == faaSTriggerTypeCron {
+		timezone := trigger.Timezone
+		if timezone == "" {
+			timezone = faaSTriggerDefaultTimezone
+		}
+		d.Set("timezone", timezone)
+	} else {
+		d.Set("timezone", "")
+	}
+	d.Set("path", trigger.Path)
+	d.Set("enable_api_key", trigger.EnableAPIKey)
+	d.Set("disabled", trigger.Disabled)
+	d.Set("status", trigger.Status)
+	d.Set("endpoint", trigger.Endpoint)
+	d.Set("created_at", trigger.CreatedAt.Format(time.RFC3339))
+	if err := d.Set("methods", trigger.Methods); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("keys", trigger.Keys); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish FaaS trigger reading")
+	return nil
+}
+
+func resourceFaaSTriggerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start FaaS trigger updating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, faasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := faaSTriggerOptsFromResource(d)
+	if err := updateFaaSTrigger(client, d.Get("namespace").(string), d.Get("function").(string), d.Get("name").(string), opts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish FaaS trigger updating")
+	return resourceFaaSTriggerRead(ctx, d, m)
+}
+
+func resourceFaaSTriggerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start FaaS trigger deleting")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, faasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = deleteFaaSTrigger(client, d.Get("namespace").(string), d.Get("function").(string), d.Get("name").(string))
+	switch err.(type) {
+	case nil, gcorecloud.ErrDefault404:
+		d.SetId("")
+		log.Println("[DEBUG] Finish of FaaS trigger deleting")
+		return nil
+	default:
+		return diag.FromErr(err)
+	}
+}
+
+func triggerID(name, fName, nsName string) string {
+	return fmt.Sprintf("%s_%s_%s", name, fName, nsName)
+}
+
+// faaSTriggerOptsFromResource returns the trigger settings which can be updated in place
+func faaSTriggerOptsFromResource(d *schema.ResourceData) faasTriggerOpts {
+	opts := faasTriggerOpts{
+		EnableAPIKey: d.Get("enable_api_key").(bool),
+		Disabled:     d.Get("disabled").(bool),
+		Keys:         []string{},
+	}
+	if d.Get("type").(string) == faaSTriggerTypeCron {
+		opts.Schedule = d.Get("schedule").(string)
+		opts.Timezone = d.Get("timezone").(string)
+	} else {
+		opts.Path = d.Get("path").(string)
+	}
+	for _, method := range d.Get("methods").(*schema.Set).List() {
+		opts.Methods = append(opts.Methods, method.(string))
+	}
+	for _, key := range d.Get("keys").(*schema.Set).List() {
+		opts.Keys = append(opts.Keys, key.(string))
+	}
+	return opts
+}
+
+// validateFaaSCronSchedule checks the schedule is a standard cron expression or a predefined one like @hourly
+func validateFaaSCronSchedule(val interface{}, key string) ([]string, []error) {
+	schedule := strings.TrimSpace(val.(string))
+	switch schedule {
+	case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+		return nil, nil
+	}
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return nil, []error{fmt.Errorf("%q must have 5 fields (minute, hour, day of month, month, day of week), got: %q", key, schedule)}
+	}
+	for i, field := range fields {
+		if !faaSCronFieldRegexps[i].MatchString(field) {
+			return nil, []error{fmt.Errorf("%q has an invalid field %q, got: %q", key, field, schedule)}
+		}
+	}
+	return nil, nil
+}
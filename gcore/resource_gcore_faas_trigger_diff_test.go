@@ -0,0 +1,108 @@
+package gcore
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceFaaSTriggerDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:   "cron",
+			config: map[string]interface{}{"type": "cron", "schedule": "*/15 3-5 * * 1,5"},
+		},
+		{
+			name:   "cron predefined",
+			config: map[string]interface{}{"type": "cron", "schedule": "@hourly"},
+		},
+		{
+			name:    "cron without schedule",
+			config:  map[string]interface{}{"type": "cron"},
+			wantErr: "schedule is required for 'cron' triggers",
+		},
+		{
+			name:    "cron with wrong schedule",
+			config:  map[string]interface{}{"type": "cron", "schedule": "0 3 * *"},
+			wantErr: "must have 5 fields",
+		},
+		{
+			name:    "cron with wrong timezone",
+			config:  map[string]interface{}{"type": "cron", "schedule": "0 3 * * *", "timezone": "Mars/Olympus"},
+			wantErr: "must be a valid timezone",
+		},
+		{
+			name:    "cron with route",
+			config:  map[string]interface{}{"type": "cron", "schedule": "0 3 * * *", "path": "/run"},
+			wantErr: "path can only be set for 'http' triggers",
+		},
+		{
+			name:   "http",
+			config: map[string]interface{}{"type": "http", "path": "/run", "methods": []interface{}{"GET", "POST"}, "enable_api_key": true, "keys": []interface{}{"key"}},
+		},
+		{
+			name:    "http without path",
+			config:  map[string]interface{}{"type": "http"},
+			wantErr: "path is required for 'http' triggers",
+		},
+		{
+			name:    "http with schedule",
+			config:  map[string]interface{}{"type": "http", "path": "/run", "schedule": "0 3 * * *"},
+			wantErr: "schedule can only be set for 'cron' triggers",
+		},
+	}
+
+	triggerResource := resourceFaaSTrigger()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"project_id": 1,
+				"region_id":  1,
+				"namespace":  "ns",
+				"function":   "fn",
+				"name":       "trigger",
+			}
+			for k, v := range tt.config {
+				raw[k] = v
+			}
+			config := terraform.NewResourceConfigRaw(raw)
+			var errs []string
+			for _, d := range triggerResource.Validate(config) {
+				errs = append(errs, d.Summary)
+			}
+			if len(errs) == 0 {
+				if _, err := triggerResource.Diff(context.Background(), nil, config, nil); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) == 0 || !strings.Contains(errs[0], tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateFaaSCronSchedule(t *testing.T) {
+	for _, schedule := range []string{"0 3 * * *", "*/15 8-18 * * MON-FRI", "0 0 1 JAN,jul *", "@daily", "30 2 * * sun"} {
+		if _, errs := validateFaaSCronSchedule(schedule, "schedule"); len(errs) > 0 {
+			t.Errorf("%q: unexpected errors %v", schedule, errs)
+		}
+	}
+	for _, schedule := range []string{"0 3 * *", "0 3 * * MONDAY", "0 MON * * *", "0 3 * JAN-XYZ *", "@every"} {
+		if _, errs := validateFaaSCronSchedule(schedule, "schedule"); len(errs) == 0 {
+			t.Errorf("%q: expected an error", schedule)
+		}
+	}
+}
@@ -2,22 +2,28 @@ package gcore
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	sdk "github.com/G-Core/FastEdge-client-sdk-go"
 )
 
 func resourceFastEdgeApp() *schema.Resource {
 	return &schema.Resource{
-		Description: "FastEdge application.",
+		Description: "FastEdge application. " +
+			"To roll back the last binary change, change `rollback_trigger`: the application is switched to `previous_binary_id` " +
+			"with the `rollout` checks and the replaced binary becomes `previous_binary_id`, so changing the trigger again rolls forward.",
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -37,9 +43,13 @@ func resourceFastEdgeApp() *schema.Resource {
 					"binary",
 					"template",
 				},
+				// the binary switched by rollback_trigger is kept until another binary is configured
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return d.Get("rolled_back").(bool) && newValue == strconv.Itoa(d.Get("previous_binary_id").(int))
+				},
 			},
 			"template": {
-				Description: "Application template id.",
+				Description: "Application template id. When set, `env` and `secrets` are checked against the template parameters at plan time.",
 				Type:        schema.TypeInt,
 				Optional:    true,
 				ExactlyOneOf: []string{
@@ -87,12 +97,186 @@ func resourceFastEdgeApp() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"url": {
+				Description: "Application URL.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_binary_id": {
+				Description: "WebAssembly binary id used before the last binary change.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"rollback_trigger": {
+				Description: "Arbitrary value, changing it to a new non-empty value switches the application to `previous_binary_id`. " +
+					"The configured `binary` is not applied again until it is changed.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"rolled_back": {
+				Description: "Whether the application runs the binary switched to by `rollback_trigger` instead of the configured `binary`.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"rollout": {
+				Description: "Binary change rollout settings. When set, the application is checked after switching to a new binary.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"health_check_url": {
+							Description:  "URL to check after switching the binary. Defaults to the application URL.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"expected_status": {
+							Description: "HTTP status code the health check URL must return.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     http.StatusOK,
+						},
+						"timeout": {
+							Description:  "Time in seconds to wait for the health check to pass.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      60,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"auto_revert": {
+							Description: "Switch back to the previous binary if the health check fails.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"delete_unused_binaries": {
+							Description: "Delete binaries that are no longer the current or the previous binary of the application, " +
+								"as well as the previous binary when the application is deleted. Binaries still referenced by any application are kept.",
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
 		},
 		CreateContext: resourceFastEdgeAppCreate,
 		ReadContext:   resourceFastEdgeAppRead,
 		UpdateContext: resourceFastEdgeAppUpdate,
 		DeleteContext: resourceFastEdgeAppDelete,
+		CustomizeDiff: resourceFastEdgeAppCustomizeDiff,
+	}
+}
+
+// plan the rollback and check env and secrets of the app against the template parameters
+func resourceFastEdgeAppCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if err := planFastEdgeAppRollback(diff); err != nil {
+		return err
+	}
+	if diff.Id() != "" && !diff.HasChanges("template", "env", "secrets") {
+		return nil
+	}
+	templateID, ok := diff.Get("template").(int)
+	if !ok || templateID == 0 || !diff.NewValueKnown("template") {
+		return nil // template is not used or not created yet
+	}
+	config, ok := m.(*Config)
+	if !ok || config.FastEdgeClient == nil {
+		return nil
+	}
+
+	rsp, err := config.FastEdgeClient.GetTemplateWithResponse(ctx, int64(templateID))
+	if err != nil {
+		return fmt.Errorf("calling GetTemplate API: %w", err)
+	}
+	if !statusOK(rsp.StatusCode()) {
+		return fmt.Errorf("calling GetTemplate API: %s", extractErrorMessage(rsp.Body))
+	}
+
+	// params are converted the same way as the param attribute of gcore_fastedge_template,
+	// env and secrets the same way as for the API request, see fastEdgeAppPayload
+	params := expandTemplateParams(flattenTemplateParams(rsp.JSON200.Params))
+	env, secrets := map[string]string{}, map[string]sdk.AppSecretShort{}
+	if v := fieldValueStringMap(diff, "env"); v != nil {
+		env = *v
+	}
+	if v := fieldValueSecretMap(diff, "secrets"); v != nil {
+		secrets = *v
+	}
+	known := func(key string) bool { return diff.NewValueKnown(key) }
+	return validateFastEdgeAppParams(int64(templateID), params, env, secrets, known)
+}
+
+// planFastEdgeAppRollback switches the planned binary to the previous one when rollback_trigger changes
+func planFastEdgeAppRollback(diff *schema.ResourceDiff) error {
+	if diff.Id() == "" || !diff.HasChange("rollback_trigger") || diff.Get("rollback_trigger").(string) == "" {
+		return nil
+	}
+	if diff.HasChange("binary") {
+		return errors.New("binary and rollback_trigger cannot be changed at the same time")
+	}
+	previous := diff.Get("previous_binary_id").(int)
+	if previous == 0 {
+		return errors.New("rollback_trigger changed, but the application has no previous binary to roll back to")
+	}
+	return diff.SetNew("binary", previous)
+}
+
+// validateFastEdgeAppParams checks that mandatory template parameters are set and values match parameter types.
+// Parameters of the secret type are set in secrets, the rest in env.
+func validateFastEdgeAppParams(templateID int64, params []sdk.TemplateParam, env map[string]string, secrets map[string]sdk.AppSecretShort, known func(string) bool) error {
+	var errs []error
+	for _, param := range params {
+		if param.DataType == sdk.TemplateParamDataTypeSecret {
+			if _, ok := secrets[param.Name]; !ok && param.Mandatory && known("secrets") {
+				errs = append(errs, fmt.Errorf("secrets: mandatory parameter %q of template %d is missing", param.Name, templateID))
+			}
+			continue
+		}
+
+		if !known("env") {
+			continue
+		}
+		value, ok := env[param.Name]
+		if !ok {
+			if param.Mandatory {
+				errs = append(errs, fmt.Errorf("env: mandatory parameter %q of template %d is missing", param.Name, templateID))
+			}
+			continue
+		}
+		if !known("env." + param.Name) {
+			continue
+		}
+		if err := validateFastEdgeParamValue(param.DataType, value); err != nil {
+			errs = append(errs, fmt.Errorf("env: parameter %q of template %d %w", param.Name, templateID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateFastEdgeParamValue(dataType sdk.TemplateParamDataType, value string) error {
+	var err error
+	switch dataType {
+	case sdk.TemplateParamDataTypeNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case sdk.TemplateParamDataTypeBool:
+		_, err = strconv.ParseBool(value)
+	case sdk.TemplateParamDataTypeDate:
+		_, err = time.Parse(time.DateOnly, value)
+	case sdk.TemplateParamDataTypeTime:
+		if _, err = time.Parse(time.TimeOnly, value); err != nil {
+			_, err = time.Parse("15:04", value)
+		}
+	case sdk.TemplateParamDataTypeJson:
+		if !json.Valid([]byte(value)) {
+			err = errors.New("invalid JSON")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("must be of type %s, got %q", dataType, value)
 	}
+	return nil
 }
 
 func resourceFastEdgeAppCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -100,18 +284,7 @@ func resourceFastEdgeAppCreate(ctx context.Context, d *schema.ResourceData, m an
 	config := m.(*Config)
 	client := config.FastEdgeClient
 
-	status := statusToInt(d.Get("status").(string))
-	app := sdk.App{
-		Name:       fieldValue[string](d, "name"),
-		Binary:     fieldValueInt64(d, "binary"),
-		Template:   fieldValueInt64(d, "template"),
-		Debug:      fieldValue[bool](d, "debug"),
-		Env:        fieldValueStringMap(d, "env"),
-		RspHeaders: fieldValueStringMap(d, "rsp_headers"),
-		Secrets:    fieldValueSecretMap(d, "secrets"),
-		Comment:    fieldValue[string](d, "comment"),
-		Status:     &status,
-	}
+	app := fastEdgeAppPayload(d)
 	rsp, err := client.AddAppWithResponse(ctx, app)
 	if err != nil {
 		return diag.Errorf("calling AddApp API: %v", err)
@@ -123,6 +296,7 @@ func resourceFastEdgeAppCreate(ctx context.Context, d *schema.ResourceData, m an
 	d.SetId(strconv.FormatInt(rsp.JSON200.Id, 10))
 	d.Set("name", rsp.JSON200.Name)
 	d.Set("binary", rsp.JSON200.Binary)
+	d.Set("url", rsp.JSON200.Url)
 	d.Set("status", statusToString(rsp.JSON200.Status)) // return status may differ from the one set by the user
 
 	log.Printf("[DEBUG] Finish FastEdge app creation (id=%d)\n", rsp.JSON200.Id)
@@ -164,6 +338,7 @@ func resourceFastEdgeAppRead(ctx context.Context, d *schema.ResourceData, m any)
 	setField(d, "env", app.Env)
 	setField(d, "rsp_headers", app.RspHeaders)
 	setField(d, "comment", app.Comment)
+	setField(d, "url", app.Url)
 	d.Set("status", statusToString(*app.Status))
 	if app.Secrets != nil {
 		secrets := make(map[string]any, len(*app.Secrets))
@@ -187,18 +362,7 @@ func resourceFastEdgeAppUpdate(ctx context.Context, d *schema.ResourceData, m an
 		return diag.Errorf("converting id to number: %v", err)
 	}
 
-	status := statusToInt(d.Get("status").(string))
-	app := sdk.App{
-		Name:       fieldValue[string](d, "name"),
-		Binary:     fieldValueInt64(d, "binary"),
-		Template:   fieldValueInt64(d, "template"),
-		Debug:      fieldValue[bool](d, "debug"),
-		Env:        fieldValueStringMap(d, "env"),
-		RspHeaders: fieldValueStringMap(d, "rsp_headers"),
-		Secrets:    fieldValueSecretMap(d, "secrets"),
-		Comment:    fieldValue[string](d, "comment"),
-		Status:     &status,
-	}
+	app := fastEdgeAppPayload(d)
 	rsp, err := client.UpdateAppWithResponse(ctx, id, sdk.UpdateAppJSONRequestBody{App: app})
 	if err != nil {
 		return diag.Errorf("calling UpdateApp API: %v", err)
@@ -219,10 +383,146 @@ func resourceFastEdgeAppUpdate(ctx context.Context, d *schema.ResourceData, m an
 	d.Set("name", rsp.JSON200.Name)                     // name may change as a result of the update
 	d.Set("status", statusToString(rsp.JSON200.Status)) // return status may differ from the one set by the user
 
+	if d.HasChange("binary") {
+		if diags := rolloutFastEdgeAppBinary(ctx, d, client, id); diags.HasError() {
+			return diags
+		}
+		// a rollback is undone by changing rollback_trigger again or by configuring another binary
+		d.Set("rolled_back", d.HasChange("rollback_trigger") && !d.Get("rolled_back").(bool))
+	}
+
 	log.Println("[DEBUG] Finish FastEdge app update")
 	return nil
 }
 
+// rolloutFastEdgeAppBinary checks the application after the binary switch and keeps the binary history
+func rolloutFastEdgeAppBinary(ctx context.Context, d *schema.ResourceData, client *sdk.ClientWithResponses, id int64) diag.Diagnostics {
+	oldBinary, newBinary := d.GetChange("binary")
+	prevBinary, _ := d.GetChange("previous_binary_id")
+	if oldBinary.(int) == 0 {
+		return nil
+	}
+
+	rollout := fastEdgeAppRollout(d)
+	if rollout != nil {
+		url := rollout["health_check_url"].(string)
+		if url == "" {
+			url = d.Get("url").(string)
+		}
+		timeout := time.Duration(rollout["timeout"].(int)) * time.Second
+		if err := waitFastEdgeAppHealthy(ctx, url, rollout["expected_status"].(int), timeout); err != nil {
+			if !rollout["auto_revert"].(bool) {
+				d.Set("previous_binary_id", oldBinary)
+				return diag.Errorf("binary %d failed health check: %v", newBinary, err)
+			}
+
+			log.Printf("[DEBUG] Reverting FastEdge app (id=%d) to binary %d\n", id, oldBinary)
+			app := fastEdgeAppPayload(d)
+			binary := int64(oldBinary.(int))
+			app.Binary = &binary
+			rsp, revertErr := client.UpdateAppWithResponse(ctx, id, sdk.UpdateAppJSONRequestBody{App: app})
+			if revertErr != nil {
+				return diag.Errorf("binary %d failed health check: %v, reverting to binary %d: %v", newBinary, err, oldBinary, revertErr)
+			}
+			if !statusOK(rsp.StatusCode()) {
+				return diag.Errorf("binary %d failed health check: %v, reverting to binary %d: %s", newBinary, err, oldBinary, extractErrorMessage(rsp.Body))
+			}
+			d.Set("binary", oldBinary)
+			return diag.Errorf("binary %d failed health check: %v, reverted to binary %d", newBinary, err, oldBinary)
+		}
+	}
+
+	d.Set("previous_binary_id", oldBinary)
+
+	// the binary dropped from the history is not needed by this app anymore
+	dropped := int64(prevBinary.(int))
+	if rollout != nil && rollout["delete_unused_binaries"].(bool) && dropped != 0 &&
+		dropped != int64(newBinary.(int)) && dropped != int64(oldBinary.(int)) {
+		return deleteUnusedFastEdgeBinary(ctx, client, dropped)
+	}
+	return nil
+}
+
+func fastEdgeAppRollout(d *schema.ResourceData) map[string]any {
+	rollout := d.Get("rollout").([]any)
+	if len(rollout) == 0 || rollout[0] == nil {
+		return nil
+	}
+	return rollout[0].(map[string]any)
+}
+
+// fastEdgeHealthCheckAttemptTimeout bounds a single health check request, a hanging one is retried
+const fastEdgeHealthCheckAttemptTimeout = 10 * time.Second
+
+func waitFastEdgeAppHealthy(ctx context.Context, url string, expectedStatus int, timeout time.Duration) error {
+	if url == "" {
+		return errors.New("health check URL is unknown")
+	}
+	// no attempt may outlive the rollout timeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		attemptCtx, cancelAttempt := context.WithTimeout(ctx, fastEdgeHealthCheckAttemptTimeout)
+		defer cancelAttempt()
+		req, err := http.NewRequestWithContext(attemptCtx, http.MethodGet, url, nil)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		rsp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		rsp.Body.Close()
+		if rsp.StatusCode != expectedStatus {
+			return retry.RetryableError(fmt.Errorf("%s returned status %d, expected %d", url, rsp.StatusCode, expectedStatus))
+		}
+		return nil
+	})
+}
+
+// deleteUnusedFastEdgeBinary deletes the binary unless it is still referenced by an application
+func deleteUnusedFastEdgeBinary(ctx context.Context, client *sdk.ClientWithResponses, id int64) diag.Diagnostics {
+	log.Printf("[DEBUG] Deleting unused FastEdge binary (id=%d)\n", id)
+	rsp, err := client.DelBinaryWithResponse(ctx, id)
+	if err != nil {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unused Wasm binary (%d) was not deleted: %v", id, err),
+			},
+		}
+	}
+	switch {
+	case statusOK(rsp.StatusCode()), rsp.StatusCode() == http.StatusNotFound:
+		return nil
+	case rsp.StatusCode() == http.StatusConflict:
+		log.Printf("[DEBUG] FastEdge binary (id=%d) is referenced, keeping it\n", id)
+		return nil
+	default:
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unused Wasm binary (%d) was not deleted: %s", id, extractErrorMessage(rsp.Body)),
+			},
+		}
+	}
+}
+
+func fastEdgeAppPayload(d *schema.ResourceData) sdk.App {
+	status := statusToInt(d.Get("status").(string))
+	return sdk.App{
+		Name:       fieldValue[string](d, "name"),
+		Binary:     fieldValueInt64(d, "binary"),
+		Template:   fieldValueInt64(d, "template"),
+		Debug:      fieldValue[bool](d, "debug"),
+		Env:        fieldValueStringMap(d, "env"),
+		RspHeaders: fieldValueStringMap(d, "rsp_headers"),
+		Secrets:    fieldValueSecretMap(d, "secrets"),
+		Comment:    fieldValue[string](d, "comment"),
+		Status:     &status,
+	}
+}
+
 func resourceFastEdgeAppDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var diags diag.Diagnostics
 	log.Println("[DEBUG] Start FastEdge app deletion")
@@ -251,6 +551,11 @@ func resourceFastEdgeAppDelete(ctx context.Context, d *schema.ResourceData, m an
 		}
 	} else {
 		d.SetId("")
+		if rollout := fastEdgeAppRollout(d); rollout != nil && rollout["delete_unused_binaries"].(bool) {
+			if prevBinary := int64(d.Get("previous_binary_id").(int)); prevBinary != 0 {
+				diags = deleteUnusedFastEdgeBinary(ctx, client, prevBinary)
+			}
+		}
 	}
 
 	log.Println("[DEBUG] Finish FastEdge app deletion")
@@ -269,6 +574,10 @@ func fieldValue[T any](d *schema.ResourceData, name string) *T {
 	return &val
 }
 
+func ptr[T any](val T) *T {
+	return &val
+}
+
 func fieldValueInt64(d *schema.ResourceData, name string) *int64 {
 	v := d.Get(name)
 	if v == nil {
@@ -282,7 +591,7 @@ func fieldValueInt64(d *schema.ResourceData, name string) *int64 {
 	return &val
 }
 
-func fieldValueStringMap(d *schema.ResourceData, name string) *map[string]string {
+func fieldValueStringMap(d interface{ Get(string) any }, name string) *map[string]string {
 	v := d.Get(name)
 	if v == nil {
 		return nil
@@ -295,7 +604,7 @@ func fieldValueStringMap(d *schema.ResourceData, name string) *map[string]string
 	return &val
 }
 
-func fieldValueSecretMap(d *schema.ResourceData, name string) *map[string]sdk.AppSecretShort {
+func fieldValueSecretMap(d interface{ Get(string) any }, name string) *map[string]sdk.AppSecretShort {
 	v := d.Get(name)
 	if v == nil {
 		return nil
@@ -333,6 +642,8 @@ func statusToInt(status string) int {
 		return 1
 	case "disabled":
 		return 2
+	case "suspended":
+		return 5
 	default:
 		return -1 // will fail on server side
 	}
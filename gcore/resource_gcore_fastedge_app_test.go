@@ -1,10 +1,16 @@
 package gcore
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	sdk "github.com/G-Core/FastEdge-client-sdk-go"
 )
@@ -292,3 +298,201 @@ func TestFastEdgeApp_import(t *testing.T) {
 
 	mock.ExpectationsWereMet(t)
 }
+
+func TestWaitFastEdgeAppHealthy(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	if err := waitFastEdgeAppHealthy(context.Background(), server.URL, http.StatusOK, 10*time.Second); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := waitFastEdgeAppHealthy(context.Background(), server.URL, http.StatusTeapot, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "expected 418") {
+		t.Errorf("expected health check failure, got: %v", err)
+	}
+
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer hanging.Close()
+
+	start := time.Now()
+	if err := waitFastEdgeAppHealthy(context.Background(), hanging.URL, http.StatusOK, time.Second); err == nil {
+		t.Errorf("expected health check failure on a hanging URL")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("health check took %s, longer than the rollout timeout", elapsed)
+	}
+}
+
+func TestFastEdgeAppRollbackTrigger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	rolledBackApp := sdk.App{
+		Name:       ptr("test-app"),
+		Binary:     ptr[int64](314),
+		Debug:      ptr(false),
+		Env:        &map[string]string{},
+		RspHeaders: &map[string]string{},
+		Secrets:    &map[string]sdk.AppSecretShort{},
+		Comment:    ptr(""),
+		Status:     ptr(1),
+	}
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"UpdateApp": {
+				params: []mockParams{
+					{
+						expectId:      42,
+						expectPayload: sdk.UpdateAppJSONRequestBody{App: rolledBackApp},
+						retStatus:     http.StatusOK,
+						retBody:       `{"id": 42, "name": "test-app", "status": 1, "binary": 314}`,
+					},
+				},
+			},
+		},
+	}
+	meta := &Config{FastEdgeClient: &sdk.ClientWithResponses{ClientInterface: mock}}
+
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"id":                 "42",
+			"name":               "test-app",
+			"status":             "enabled",
+			"binary":             "315",
+			"previous_binary_id": "314",
+			"url":                server.URL,
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":             "test-app",
+		"status":           "enabled",
+		"binary":           315,
+		"rollback_trigger": "bad release",
+		"rollout":          []interface{}{map[string]interface{}{"timeout": 5}},
+	})
+	app := resourceFastEdgeApp()
+
+	diff, err := app.Diff(context.Background(), state, config, meta)
+	if err != nil || diff.Attributes["binary"] == nil || diff.Attributes["binary"].New != "314" {
+		t.Fatalf("expected binary 314 to be planned, got %+v, %v", diff, err)
+	}
+	state, diags := app.Apply(context.Background(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	for key, want := range map[string]string{"binary": "314", "previous_binary_id": "315", "rolled_back": "true"} {
+		if state.Attributes[key] != want {
+			t.Errorf("%s = %q, want %q", key, state.Attributes[key], want)
+		}
+	}
+
+	// the configured binary is not applied again
+	diff, err = app.Diff(context.Background(), state, config, meta)
+	if err != nil || (diff != nil && diff.Attributes["binary"] != nil) {
+		t.Errorf("unexpected binary change after the rollback: %+v, %v", diff, err)
+	}
+	mock.ExpectationsWereMet(t)
+
+	state = &terraform.InstanceState{
+		ID:         "42",
+		Attributes: map[string]string{"id": "42", "name": "test-app", "status": "enabled", "binary": "315"},
+	}
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":             "test-app",
+		"status":           "enabled",
+		"binary":           315,
+		"rollback_trigger": "another release",
+	})
+	if _, err := app.Diff(context.Background(), state, config, meta); err == nil || !strings.Contains(err.Error(), "no previous binary") {
+		t.Errorf("expected an error without a previous binary, got: %v", err)
+	}
+}
+
+func TestDeleteUnusedFastEdgeBinary(t *testing.T) {
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"DelBinary": {
+				params: []mockParams{
+					{
+						expectId:  314,
+						retStatus: http.StatusNoContent,
+					},
+					{
+						expectId:  315,
+						retStatus: http.StatusConflict, // referenced by another app
+					},
+					{
+						expectId:  316,
+						retStatus: http.StatusInternalServerError,
+						retBody:   `{"error": "internal error"}`,
+					},
+				},
+			},
+		},
+	}
+	client := &sdk.ClientWithResponses{ClientInterface: mock}
+
+	if diags := deleteUnusedFastEdgeBinary(context.Background(), client, 314); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := deleteUnusedFastEdgeBinary(context.Background(), client, 315); len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := deleteUnusedFastEdgeBinary(context.Background(), client, 316); len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning, got: %v", diags)
+	}
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestValidateFastEdgeAppParams(t *testing.T) {
+	params := []sdk.TemplateParam{
+		{Name: "count", DataType: sdk.TemplateParamDataTypeNumber, Mandatory: true},
+		{Name: "since", DataType: sdk.TemplateParamDataTypeDate},
+		{Name: "at", DataType: sdk.TemplateParamDataTypeTime},
+		{Name: "title", DataType: sdk.TemplateParamDataTypeString},
+		{Name: "token", DataType: sdk.TemplateParamDataTypeSecret, Mandatory: true},
+	}
+	known := func(string) bool { return true }
+
+	env := map[string]string{"count": "42", "since": "2024-02-29", "at": "12:30", "title": "abc"}
+	secrets := map[string]sdk.AppSecretShort{"token": {Id: 1}}
+	if err := validateFastEdgeAppParams(7, params, env, secrets, known); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	env = map[string]string{"since": "29.02.2024", "at": "25:00"}
+	err := validateFastEdgeAppParams(7, params, env, map[string]sdk.AppSecretShort{}, known)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, msg := range []string{
+		`env: mandatory parameter "count" of template 7 is missing`,
+		`env: parameter "since" of template 7 must be of type date, got "29.02.2024"`,
+		`env: parameter "at" of template 7 must be of type time, got "25:00"`,
+		`secrets: mandatory parameter "token" of template 7 is missing`,
+	} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected %q in error: %v", msg, err)
+		}
+	}
+
+	// values not known until apply are not validated
+	env = map[string]string{"count": "74D93920-ED26-11E3-AC10-0800200C9A66"}
+	unknown := func(key string) bool { return key != "env.count" }
+	if err := validateFastEdgeAppParams(7, params, env, secrets, unknown); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
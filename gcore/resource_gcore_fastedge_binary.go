@@ -1,21 +1,38 @@
 package gcore
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// fastEdgeHandlerExports are the interfaces FastEdge calls to handle requests, including the proxy-wasm ABI
+var fastEdgeHandlerExports = []string{"gcore:fastedge/http-handler", "wasi:http/incoming-handler", "proxy_on_request_headers"}
+
+var fastEdgeBinarySources = []string{"filename", "content_base64", "source_url"}
+
+// fastEdgeBinaryDownloadClient fetches source_url, also during plan, so a stalled server can't hang Terraform
+var fastEdgeBinaryDownloadClient = &http.Client{Timeout: 5 * time.Minute}
+
 func resourceFastEdgeBinary() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
@@ -23,10 +40,10 @@ func resourceFastEdgeBinary() *schema.Resource {
 		},
 		Schema: map[string]*schema.Schema{
 			"filename": {
-				Description: "WebAssembly binary file to upload.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true, // new resource on filename change
+				Description:  "WebAssembly binary file to upload.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: fastEdgeBinarySources,
 				// make sure file exists and is readable
 				ValidateFunc: func(v any, k string) ([]string, []error) {
 					f, err := os.Open(v.(string))
@@ -37,26 +54,92 @@ func resourceFastEdgeBinary() *schema.Resource {
 					return nil, nil
 				},
 			},
+			"content_base64": {
+				Description:  "Base64-encoded WebAssembly binary to upload.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: fastEdgeBinarySources,
+				ValidateFunc: validation.StringIsBase64,
+			},
+			"source_url": {
+				Description: "HTTP(S) URL to download the WebAssembly binary from, e.g. a CI artifact or a pre-signed S3 object URL. " +
+					"Requires `sha256`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: fastEdgeBinarySources,
+				RequiredWith: []string{"sha256"},
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"sha256": {
+				Description: "SHA-256 checksum of the binary. Required with `source_url`, otherwise calculated from the binary. " +
+					"A change of the checksum replaces the binary.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9a-f]{64}$`), "must be a lowercase hex-encoded SHA-256 checksum"),
+			},
 			"checksum": {
 				Description: "Binary checksum.",
 				Type:        schema.TypeString,
 				Computed:    true,
-				ForceNew:    true, // new resource on file content change
 			},
 		},
 		CreateContext: resourceFastEdgeBinaryUpload,
 		ReadContext:   resourceFastEdgeBinaryRead,
+		UpdateContext: resourceFastEdgeBinaryUpdate,
 		DeleteContext: resourceFastEdgeBinaryDelete,
-		Description:   "WebAssembly binary to use in FastEdge applications.",
-		// calculate file checksum to detect file content change
-		CustomizeDiff: func(_ context.Context, diff *schema.ResourceDiff, meta any) error {
-			checksum, err := fileChecksum(diff.Get("filename").(string))
-			if err != nil {
-				return nil
-			}
-			return diff.SetNew("checksum", checksum)
-		},
+		Description: "WebAssembly binary to use in FastEdge applications. " +
+			"The binary must be a WebAssembly core module or component exporting a FastEdge request handler, it is validated at plan time.",
+		CustomizeDiff: resourceFastEdgeBinaryCustomizeDiff,
+	}
+}
+
+// calculate binary SHA-256 to detect content change and validate the binary at plan time
+func resourceFastEdgeBinaryCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+	for _, key := range append(fastEdgeBinarySources, "sha256") {
+		if !diff.NewValueKnown(key) {
+			return nil // binary is not known until apply
+		}
+	}
+
+	expected := ""
+	if config := diff.GetRawConfig(); !config.IsNull() && !config.GetAttr("sha256").IsNull() {
+		expected = diff.Get("sha256").(string)
+	}
+	old, _ := diff.GetChange("sha256")
+	oldChecksum := old.(string)
+
+	// remote binary is downloaded only when the expected checksum changes
+	if diff.Get("source_url").(string) != "" && diff.Id() != "" && oldChecksum == expected {
+		return nil
+	}
+
+	payload, err := loadFastEdgeBinary(ctx, diff)
+	if err != nil {
+		return err
 	}
+	checksum := sha256Checksum(payload)
+	if expected != "" && checksum != expected {
+		return fmt.Errorf("binary SHA-256 (%s) does not match expected (%s)", checksum, expected)
+	}
+	if err := validateWasm(payload); err != nil {
+		return err
+	}
+
+	if checksum == oldChecksum {
+		return nil
+	}
+	if err := diff.SetNew("sha256", checksum); err != nil {
+		return err
+	}
+	if diff.Id() == "" {
+		return nil
+	}
+	// binaries uploaded before SHA-256 was tracked are only replaced if the content differs
+	if oldChecksum == "" && md5Checksum(payload) == diff.Get("checksum").(string) {
+		return nil
+	}
+	return diff.ForceNew("sha256")
 }
 
 func resourceFastEdgeBinaryUpload(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -64,14 +147,18 @@ func resourceFastEdgeBinaryUpload(ctx context.Context, d *schema.ResourceData, m
 	config := m.(*Config)
 	client := config.FastEdgeClient
 
-	filename := d.Get("filename").(string)
-	wasmFile, err := os.Open(filename)
+	payload, err := loadFastEdgeBinary(ctx, d)
 	if err != nil {
-		return diag.Errorf("opening file %s: %v", filename, err)
+		return diag.FromErr(err)
 	}
-	defer wasmFile.Close()
 
-	rsp, err := client.StoreBinaryWithBodyWithResponse(ctx, "application/octet-stream", wasmFile)
+	// make sure binary was not changed since plan
+	checksum := sha256Checksum(payload)
+	if expected := d.Get("sha256").(string); expected != "" && checksum != expected {
+		return diag.Errorf("binary SHA-256 (%s) does not match expected (%s)", checksum, expected)
+	}
+
+	rsp, err := client.StoreBinaryWithBodyWithResponse(ctx, "application/octet-stream", bytes.NewReader(payload))
 	if err != nil {
 		return diag.Errorf("calling StoreBinary API: %v", err)
 	}
@@ -80,18 +167,25 @@ func resourceFastEdgeBinaryUpload(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// make sure binary was not damaged in transit
-	expectedChecksum := d.Get("checksum").(string)
+	expectedChecksum := md5Checksum(payload)
 	if *rsp.JSON200.Checksum != expectedChecksum {
 		// binary damaged in transit
 		return diag.Errorf("uploaded binary checksum (%s) does not match expected (%s), please retry", *rsp.JSON200.Checksum, expectedChecksum)
 	}
 
 	d.SetId(strconv.FormatInt(rsp.JSON200.Id, 10))
+	d.Set("sha256", checksum)
+	d.Set("checksum", rsp.JSON200.Checksum)
 
 	log.Printf("[DEBUG] Finish FastEdge binary upload (id=%d)\n", rsp.JSON200.Id)
 	return nil
 }
 
+// binary content is immutable, so only the source of the same content can change in place
+func resourceFastEdgeBinaryUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	return resourceFastEdgeBinaryRead(ctx, d, m)
+}
+
 func resourceFastEdgeBinaryDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var diags diag.Diagnostics
 	log.Println("[DEBUG] Start FastEdge binary deletion")
@@ -113,6 +207,8 @@ func resourceFastEdgeBinaryDelete(ctx context.Context, d *schema.ResourceData, m
 				{
 					Severity: diag.Warning,
 					Summary:  fmt.Sprintf("Wasm binary (%d) is referenced so cannot be deleted", id),
+					Detail: "Applications with `rollout.delete_unused_binaries` enabled delete the binary " +
+						"once it is no longer their current or previous binary.",
 				},
 			}
 		} else {
@@ -172,17 +268,163 @@ func statusOK(status int) bool {
 	return status == http.StatusOK || status == http.StatusNoContent
 }
 
-func fileChecksum(filename string) (string, error) {
-	file, err := os.Open(filename)
+func md5Checksum(payload []byte) string {
+	hash := md5.Sum(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+func sha256Checksum(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+// loadFastEdgeBinary reads the binary from the configured source
+func loadFastEdgeBinary(ctx context.Context, d interface{ Get(string) any }) ([]byte, error) {
+	if url := d.Get("source_url").(string); url != "" {
+		return downloadFastEdgeBinary(ctx, url)
+	}
+	return loadLocalFastEdgeBinary(d)
+}
+
+// loadLocalFastEdgeBinary reads the binary from filename or content_base64
+func loadLocalFastEdgeBinary(d interface{ Get(string) any }) ([]byte, error) {
+	if filename := d.Get("filename").(string); filename != "" {
+		payload, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("opening file %s: %w", filename, err)
+		}
+		return payload, nil
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(d.Get("content_base64").(string))
+	if err != nil {
+		return nil, fmt.Errorf("decoding content_base64: %w", err)
+	}
+	return payload, nil
+}
+
+func downloadFastEdgeBinary(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
+	}
+	rsp, err := fastEdgeBinaryDownloadClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: unexpected status %s", url, rsp.Status)
+	}
+	payload, err := io.ReadAll(rsp.Body)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
 	}
-	defer file.Close()
+	return payload, nil
+}
 
-	hash := md5.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", err
+// validateWasm checks that the payload is a core module or a component exporting a FastEdge request handler
+func validateWasm(payload []byte) error {
+	if len(payload) < 8 || !bytes.Equal(payload[:4], []byte("\x00asm")) {
+		return errors.New("binary is not a WebAssembly module: invalid magic bytes")
 	}
 
-	return hex.EncodeToString(hash.Sum(nil)), nil
+	version := binary.LittleEndian.Uint16(payload[4:6])
+	layer := binary.LittleEndian.Uint16(payload[6:8])
+	switch {
+	case layer == 0 && version == 1:
+		exports, err := wasmCoreExports(payload[8:])
+		if err != nil {
+			return fmt.Errorf("binary is not a valid WebAssembly module: %w", err)
+		}
+		for _, export := range exports {
+			for _, handler := range fastEdgeHandlerExports {
+				if strings.HasPrefix(export, handler) {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("WebAssembly module does not export a request handler, expected one of %s", strings.Join(fastEdgeHandlerExports, ", "))
+	case layer == 1:
+		// component export names are plain strings, no need to decode the whole section
+		sections, err := wasmSections(payload[8:])
+		if err != nil {
+			return fmt.Errorf("binary is not a valid WebAssembly component: %w", err)
+		}
+		for _, section := range sections[wasmComponentExportSection] {
+			for _, handler := range fastEdgeHandlerExports {
+				if bytes.Contains(section, []byte(handler)) {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("WebAssembly component does not export a request handler, expected one of %s", strings.Join(fastEdgeHandlerExports, ", "))
+	default:
+		return fmt.Errorf("unsupported WebAssembly version %d (layer %d)", version, layer)
+	}
+}
+
+const (
+	wasmCoreExportSection      = 7
+	wasmComponentExportSection = 11
+	wasmExportKindFunc         = 0
+)
+
+// wasmSections splits the binary body into section payloads grouped by section id
+func wasmSections(body []byte) (map[byte][][]byte, error) {
+	sections := map[byte][][]byte{}
+	for len(body) > 0 {
+		id := body[0]
+		size, n := wasmULEB128(body[1:])
+		if n == 0 || uint64(len(body)-1-n) < size {
+			return nil, fmt.Errorf("section %d is truncated", id)
+		}
+		body = body[1+n:]
+		sections[id] = append(sections[id], body[:size])
+		body = body[size:]
+	}
+	return sections, nil
+}
+
+// wasmCoreExports returns the names of the functions exported by a core module
+func wasmCoreExports(body []byte) ([]string, error) {
+	sections, err := wasmSections(body)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, section := range sections[wasmCoreExportSection] {
+		count, n := wasmULEB128(section)
+		section = section[n:]
+		for i := uint64(0); i < count; i++ {
+			length, n := wasmULEB128(section)
+			if n == 0 || length >= uint64(len(section)-n) {
+				return nil, errors.New("export section is truncated")
+			}
+			name := string(section[n : n+int(length)])
+			kind := section[n+int(length)]
+			section = section[n+int(length)+1:]
+			if _, n = wasmULEB128(section); n == 0 {
+				return nil, errors.New("export section is truncated")
+			}
+			section = section[n:]
+			if kind == wasmExportKindFunc {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// wasmULEB128 decodes an unsigned LEB128 number, returns the number of bytes read or 0 on error
+func wasmULEB128(buf []byte) (uint64, int) {
+	var result uint64
+	for i := 0; i < len(buf) && i < 10; i++ {
+		result |= uint64(buf[i]&0x7f) << (7 * i)
+		if buf[i]&0x80 == 0 {
+			return result, i + 1
+		}
+	}
+	return 0, 0
 }
@@ -1,8 +1,11 @@
 package gcore
 
 import (
+	"encoding/base64"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -10,7 +13,7 @@ import (
 )
 
 func TestFastEdgeBinary_basic(t *testing.T) {
-	checksum, _ := fileChecksum(os.Args[0])
+	filename, checksum := testWasmFile(t)
 	mock := &mockSDK{
 		t: t,
 		mocks: map[string]*funcMock{
@@ -47,7 +50,7 @@ func TestFastEdgeBinary_basic(t *testing.T) {
 		IsUnitTest:        true,
 		Steps: []resource.TestStep{
 			{
-				Config: `resource "gcore_fastedge_binary" "test" {	filename = "` + os.Args[0] + `"}`,
+				Config: `resource "gcore_fastedge_binary" "test" {	filename = "` + filename + `"}`,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "id", "42"),
 					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "checksum", checksum),
@@ -60,6 +63,7 @@ func TestFastEdgeBinary_basic(t *testing.T) {
 }
 
 func TestFastEdgeBinary_corrupted(t *testing.T) {
+	filename, _ := testWasmFile(t)
 	mock := &mockSDK{
 		t: t,
 		mocks: map[string]*funcMock{
@@ -79,7 +83,7 @@ func TestFastEdgeBinary_corrupted(t *testing.T) {
 		IsUnitTest:        true,
 		Steps: []resource.TestStep{
 			{
-				Config:      `resource "gcore_fastedge_binary" "test" {	filename = "` + os.Args[0] + `"}`,
+				Config:      `resource "gcore_fastedge_binary" "test" {	filename = "` + filename + `"}`,
 				ExpectError: regexp.MustCompile(`uploaded binary checksum \(xyz\) does not match expected \(.*\), please retry`),
 			},
 		},
@@ -89,7 +93,7 @@ func TestFastEdgeBinary_corrupted(t *testing.T) {
 }
 
 func TestFastEdgeBinary_disappear(t *testing.T) {
-	checksum, _ := fileChecksum(os.Args[0])
+	filename, checksum := testWasmFile(t)
 	mock := &mockSDK{
 		t: t,
 		mocks: map[string]*funcMock{
@@ -139,14 +143,14 @@ func TestFastEdgeBinary_disappear(t *testing.T) {
 		IsUnitTest:        true,
 		Steps: []resource.TestStep{
 			{
-				Config: `resource "gcore_fastedge_binary" "test" {	filename = "` + os.Args[0] + `"}`,
+				Config: `resource "gcore_fastedge_binary" "test" {	filename = "` + filename + `"}`,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "id", "42"),
 					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "checksum", checksum),
 				),
 			},
 			{
-				Config: `resource "gcore_fastedge_binary" "test" {	filename = "` + os.Args[0] + `"}`,
+				Config: `resource "gcore_fastedge_binary" "test" {	filename = "` + filename + `"}`,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "id", "43"),
 					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "checksum", checksum),
@@ -159,7 +163,7 @@ func TestFastEdgeBinary_disappear(t *testing.T) {
 }
 
 func TestFastEdgeBinary_import(t *testing.T) {
-	checksum, _ := fileChecksum(os.Args[0])
+	filename, checksum := testWasmFile(t)
 	mock := &mockSDK{
 		t: t,
 		mocks: map[string]*funcMock{
@@ -180,7 +184,7 @@ func TestFastEdgeBinary_import(t *testing.T) {
 		IsUnitTest:        true,
 		Steps: []resource.TestStep{
 			{
-				Config:        `resource "gcore_fastedge_binary" "test" {	filename = "` + os.Args[0] + `"}`,
+				Config:        `resource "gcore_fastedge_binary" "test" {	filename = "` + filename + `"}`,
 				ImportState:   true,
 				ImportStateId: "42",
 				ResourceName:  "gcore_fastedge_binary.test",
@@ -194,3 +198,224 @@ func TestFastEdgeBinary_import(t *testing.T) {
 
 	mock.ExpectationsWereMet(t)
 }
+
+func TestFastEdgeBinary_contentBase64(t *testing.T) {
+	payload := testWasmModule("gcore:fastedge/http-handler#process")
+	checksum := md5Checksum(payload)
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"GetBinary": {
+				params: []mockParams{
+					{
+						expectId:  42,
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, "checksum": "` + checksum + `"}`,
+					},
+				},
+			},
+			"StoreBinary": {
+				params: []mockParams{
+					{
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, "checksum": "` + checksum + `"}`,
+					},
+				},
+			},
+			"DelBinary": {
+				params: []mockParams{
+					{
+						expectId:  42,
+						retStatus: http.StatusNoContent,
+					},
+				},
+			},
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: fastedgeMockProvider(mock),
+		IsUnitTest:        true,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "gcore_fastedge_binary" "test" {	content_base64 = "` + base64.StdEncoding.EncodeToString(payload) + `"}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "id", "42"),
+					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "checksum", checksum),
+					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "sha256", sha256Checksum(payload)),
+				),
+			},
+		},
+	})
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestFastEdgeBinary_sourceURL(t *testing.T) {
+	payload := testWasmModule("gcore:fastedge/http-handler#process")
+	checksum := md5Checksum(payload)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"GetBinary": {
+				params: []mockParams{
+					{
+						expectId:  42,
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, "checksum": "` + checksum + `"}`,
+					},
+				},
+			},
+			"StoreBinary": {
+				params: []mockParams{
+					{
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, "checksum": "` + checksum + `"}`,
+					},
+				},
+			},
+			"DelBinary": {
+				params: []mockParams{
+					{
+						expectId:  42,
+						retStatus: http.StatusNoContent,
+					},
+				},
+			},
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: fastedgeMockProvider(mock),
+		IsUnitTest:        true,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "gcore_fastedge_binary" "test" {
+					source_url = "` + server.URL + `/app.wasm"
+					sha256     = "` + sha256Checksum([]byte("something else")) + `"
+				}`,
+				ExpectError: regexp.MustCompile(`binary SHA-256 \(.*\) does not match expected \(.*\)`),
+			},
+			{
+				Config: `resource "gcore_fastedge_binary" "test" {
+					source_url = "` + server.URL + `/app.wasm"
+					sha256     = "` + sha256Checksum(payload) + `"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "id", "42"),
+					resource.TestCheckResourceAttr("gcore_fastedge_binary.test", "checksum", checksum),
+				),
+			},
+		},
+	})
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestFastEdgeBinary_invalidWasm(t *testing.T) {
+	mock := &mockSDK{t: t}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: fastedgeMockProvider(mock),
+		IsUnitTest:        true,
+		Steps: []resource.TestStep{
+			{
+				Config:      `resource "gcore_fastedge_binary" "test" {	filename = "` + os.Args[0] + `"}`,
+				ExpectError: regexp.MustCompile(`binary is not a WebAssembly module`),
+			},
+		},
+	})
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestValidateWasm(t *testing.T) {
+	component := []byte("\x00asm\x0d\x00\x01\x00")
+	export := []byte("\x00\x1awasi:http/incoming-handler")
+	component = append(component, wasmComponentExportSection, byte(len(export)))
+	component = append(component, export...)
+
+	// export name length of 2^64-1
+	overflow := []byte("\x00asm\x01\x00\x00\x00")
+	overflow = append(overflow, wasmCoreExportSection, 12, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x00)
+
+	tests := []struct {
+		name    string
+		payload []byte
+		wantErr string
+	}{
+		{
+			name:    "core module",
+			payload: testWasmModule("gcore:fastedge/http-handler#process"),
+		},
+		{
+			name:    "proxy-wasm module",
+			payload: testWasmModule("proxy_on_request_headers"),
+		},
+		{
+			name:    "component",
+			payload: component,
+		},
+		{
+			name:    "not wasm",
+			payload: []byte("#!/bin/sh"),
+			wantErr: "invalid magic bytes",
+		},
+		{
+			name:    "no handler",
+			payload: testWasmModule("main"),
+			wantErr: "does not export a request handler",
+		},
+		{
+			name:    "truncated",
+			payload: testWasmModule("gcore:fastedge/http-handler#process")[:20],
+			wantErr: "truncated",
+		},
+		{
+			name:    "export name length overflow",
+			payload: overflow,
+			wantErr: "export section is truncated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWasm(tt.payload)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !regexp.MustCompile(tt.wantErr).MatchString(err.Error())) {
+				t.Errorf("expected error %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// testWasmModule builds a minimal core module exporting a single function
+func testWasmModule(exportName string) []byte {
+	export := append([]byte{0x01, byte(len(exportName))}, exportName...)
+	export = append(export, wasmExportKindFunc, 0x00)
+
+	module := []byte("\x00asm\x01\x00\x00\x00")
+	module = append(module, 0x01, 0x04, 0x01, 0x60, 0x00, 0x00) // type section: () -> ()
+	module = append(module, 0x03, 0x02, 0x01, 0x00)             // function section
+	module = append(module, wasmCoreExportSection, byte(len(export)))
+	module = append(module, export...)
+	module = append(module, 0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b) // code section: empty body
+	return module
+}
+
+func testWasmFile(t *testing.T) (string, string) {
+	t.Helper()
+	payload := testWasmModule("gcore:fastedge/http-handler#process")
+	filename := filepath.Join(t.TempDir(), "test.wasm")
+	if err := os.WriteFile(filename, payload, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filename, md5Checksum(payload)
+}
@@ -2,16 +2,21 @@ package gcore
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	sdk "github.com/G-Core/FastEdge-client-sdk-go"
 )
@@ -36,20 +41,11 @@ func resourceFastEdgeSecret() *schema.Resource {
 				Description: "Secret description.",
 			},
 			"slot": {
-				Description: "Secret slot.",
+				Description: "Secret slot. Computed when `rotation` is used.",
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Computed:    true,
-				Set: func(val any) int {
-					// for existing resources old secret value (from state) is empty while new
-					// is not therefore default hash differs even for unchanged value, so
-					// exclude 'value' from hash calcuilation
-					var buf bytes.Buffer
-					slot := val.(map[string]any)
-					buf.WriteString(strconv.Itoa(slot["id"].(int)))
-					buf.WriteString(slot["checksum"].(string))
-					return schema.HashString(buf.String())
-				},
+				Set:         secretSlotHash,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -75,35 +71,75 @@ func resourceFastEdgeSecret() *schema.Resource {
 					},
 				},
 			},
+			"rotation": {
+				Description: "Time-based secret rotation. A new value is added as a slot with its activation timestamp as the slot id, " +
+					"slots superseded by more than `keep` newer active slots are removed.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"slot"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": {
+							Description: "Current secret value. Changing it adds a new slot. The value is not stored in the state.",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							// prevent writing secret value to state
+							StateFunc: func(val any) string {
+								return ""
+							},
+						},
+						"active_from": {
+							Description:  "Activation time of the value, in RFC 3339 format. If not set, the value is activated immediately.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"keep": {
+							Description:  "Number of previous slots to keep after the value becomes active.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
 		},
 		CreateContext: resourceFastEdgeSecretCreate,
 		ReadContext:   resourceFastEdgeSecretRead,
 		UpdateContext: resourceFastEdgeSecretUpdate,
 		DeleteContext: resourceFastEdgeSecretDelete,
-		CustomizeDiff: func(_ context.Context, diff *schema.ResourceDiff, meta any) error {
-			if diff.GetRawState().IsNull() { // adding new resource - not need for checksum
-				return nil
-			}
-			if v, ok := diff.Get("slot").(*schema.Set); ok {
-				res := schema.NewSet(v.F, nil)
-				list := v.List()
-				for _, v := range list {
-					p := v.(map[string]any)
-					value := p["value"].(string)
-					checksum := secretChecksum(value)
-					res.Add(map[string]any{
-						"id":       p["id"],
-						"checksum": checksum,
-						"value":    value,
-					})
-				}
-				diff.SetNew("slot", res)
-			}
-			return nil
-		},
+		CustomizeDiff: resourceFastEdgeSecretCustomizeDiff,
 	}
 }
 
+func resourceFastEdgeSecretCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta any) error {
+	if _, ok := diff.GetOk("rotation"); ok {
+		return planSecretRotation(diff, time.Now())
+	}
+	if diff.GetRawState().IsNull() { // adding new resource - not need for checksum
+		return nil
+	}
+	if v, ok := diff.Get("slot").(*schema.Set); ok {
+		res := schema.NewSet(v.F, nil)
+		list := v.List()
+		for _, v := range list {
+			p := v.(map[string]any)
+			value := p["value"].(string)
+			checksum := secretChecksum(value)
+			res.Add(map[string]any{
+				"id":       p["id"],
+				"checksum": checksum,
+				"value":    value,
+			})
+		}
+		diff.SetNew("slot", res)
+	}
+	return nil
+}
+
 func resourceFastEdgeSecretCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	log.Println("[DEBUG] Start FastEdge template creation")
 	config := m.(*Config)
@@ -114,6 +150,13 @@ func resourceFastEdgeSecretCreate(ctx context.Context, d *schema.ResourceData, m
 		Comment:     fieldValue[string](d, "comment"),
 		SecretSlots: getSlots(d),
 	}
+	if _, ok := d.GetOk("rotation"); ok {
+		slots, err := getRotationSlots(d, time.Now())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		secret.SecretSlots = slots
+	}
 
 	rsp, err := client.AddSecretWithResponse(ctx, sdk.AddSecretJSONRequestBody{Secret: secret})
 	if err != nil {
@@ -179,6 +222,9 @@ func resourceFastEdgeSecretUpdate(ctx context.Context, d *schema.ResourceData, m
 		Comment:     fieldValue[string](d, "comment"),
 		SecretSlots: getSlots(d),
 	}
+	if _, ok := d.GetOk("rotation"); ok {
+		return resourceFastEdgeSecretRotate(ctx, d, client, id, secret)
+	}
 
 	rsp, err := client.UpdateSecretWithResponse(ctx, id, sdk.UpdateSecretJSONRequestBody{Secret: secret})
 	if err != nil {
@@ -203,6 +249,28 @@ func resourceFastEdgeSecretUpdate(ctx context.Context, d *schema.ResourceData, m
 	return nil
 }
 
+// resourceFastEdgeSecretRotate patches the secret with rotated slots, slots sent without a value keep the stored value
+func resourceFastEdgeSecretRotate(ctx context.Context, d *schema.ResourceData, client *sdk.ClientWithResponses, id int64, secret sdk.Secret) diag.Diagnostics {
+	slots, err := getRotationSlots(d, time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	secret.SecretSlots = slots
+
+	rsp, err := client.PatchSecretWithResponse(ctx, id, sdk.PatchSecretJSONRequestBody(secret))
+	if err != nil {
+		return diag.Errorf("calling PatchSecret API: %v", err)
+	}
+	if !statusOK(rsp.StatusCode()) {
+		return diag.Errorf("calling PatchSecret API: %s", extractErrorMessage(rsp.Body))
+	}
+
+	d.Set("slot", parseSecretSlots(rsp.JSON200.SecretSlots))
+
+	log.Println("[DEBUG] Finish FastEdge secret rotation")
+	return nil
+}
+
 func resourceFastEdgeSecretDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var diags diag.Diagnostics
 	log.Println("[DEBUG] Start FastEdge secret deletion")
@@ -268,6 +336,152 @@ func parseSecretSlots(slots *[]sdk.SecretSlot) []map[string]any {
 	return nil
 }
 
+// secretSlotHash excludes 'value' from hash calculation: for existing resources old secret value (from state)
+// is empty while new is not, therefore default hash differs even for unchanged value
+func secretSlotHash(val any) int {
+	var buf bytes.Buffer
+	slot := val.(map[string]any)
+	buf.WriteString(strconv.Itoa(slot["id"].(int)))
+	buf.WriteString(slot["checksum"].(string))
+	return schema.HashString(buf.String())
+}
+
+type rotatedSlot struct {
+	id       int64
+	checksum string
+}
+
+// planSecretRotation shows rotated slots in the plan. Slot ids activated at apply time are not known in advance.
+func planSecretRotation(diff *schema.ResourceDiff, now time.Time) error {
+	value, ok := secretRotationValue(diff.GetRawConfig(), diff.Get)
+	if !ok || !diff.NewValueKnown("rotation.0.active_from") {
+		return diff.SetNewComputed("slot")
+	}
+	activeFrom, err := rotationActiveFrom(diff.Get("rotation.0.active_from").(string))
+	if err != nil {
+		return err
+	}
+
+	old, _ := diff.GetChange("slot")
+	slots := rotatedSlotsFromSet(old.(*schema.Set))
+	result, added := rotateSecretSlots(slots, secretChecksum(value), activeFrom, now.Unix(), diff.Get("rotation.0.keep").(int))
+	if added != 0 && activeFrom == 0 {
+		return diff.SetNewComputed("slot")
+	}
+	if slices.Equal(result, slots) {
+		return nil
+	}
+
+	set := schema.NewSet(secretSlotHash, nil)
+	for _, slot := range result {
+		set.Add(map[string]any{"id": int(slot.id), "checksum": slot.checksum, "value": ""})
+	}
+	return diff.SetNew("slot", set)
+}
+
+// getRotationSlots returns the slots to send to the API, only the added slot has a value
+func getRotationSlots(d *schema.ResourceData, now time.Time) (*[]sdk.SecretSlot, error) {
+	value, ok := secretRotationValue(d.GetRawConfig(), d.Get)
+	if !ok {
+		return nil, fmt.Errorf("rotation value is not known")
+	}
+	activeFrom, err := rotationActiveFrom(d.Get("rotation.0.active_from").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	old, _ := d.GetChange("slot")
+	result, added := rotateSecretSlots(rotatedSlotsFromSet(old.(*schema.Set)), secretChecksum(value), activeFrom, now.Unix(), d.Get("rotation.0.keep").(int))
+	slots := make([]sdk.SecretSlot, len(result))
+	for i, slot := range result {
+		slots[i] = sdk.SecretSlot{Slot: slot.id}
+		if slot.id == added {
+			slots[i].Value = &value
+		}
+	}
+	return &slots, nil
+}
+
+// rotateSecretSlots adds a slot with the checksum activated at activeFrom (now, if 0) unless the value is already there,
+// and drops slots superseded by more than keep newer slots active at now. Slots are sorted by id.
+// Returns the id of the added slot, or 0 if the value is not changed.
+func rotateSecretSlots(slots []rotatedSlot, checksum string, activeFrom, now int64, keep int) ([]rotatedSlot, int64) {
+	result := slices.Clone(slots)
+	slices.SortFunc(result, func(a, b rotatedSlot) int { return cmp.Compare(a.id, b.id) })
+
+	var added bool
+	if activeFrom == 0 {
+		added = len(result) == 0 || result[len(result)-1].checksum != checksum
+		activeFrom = now
+	} else {
+		added = !slices.Contains(result, rotatedSlot{activeFrom, checksum})
+	}
+	if added {
+		result = slices.DeleteFunc(result, func(s rotatedSlot) bool { return s.id == activeFrom })
+		result = append(result, rotatedSlot{activeFrom, checksum})
+		slices.SortFunc(result, func(a, b rotatedSlot) int { return cmp.Compare(a.id, b.id) })
+	}
+
+	// result[active] is the slot in use now
+	active := -1
+	for i, slot := range result {
+		if slot.id <= now {
+			active = i
+		}
+	}
+	if first := active - keep; first > 0 {
+		result = result[first:]
+	}
+	if !added {
+		return result, 0
+	}
+	return result, activeFrom
+}
+
+func rotatedSlotsFromSet(set *schema.Set) []rotatedSlot {
+	slots := []rotatedSlot{}
+	if set == nil {
+		return slots
+	}
+	for _, v := range set.List() {
+		p := v.(map[string]any)
+		slots = append(slots, rotatedSlot{int64(p["id"].(int)), p["checksum"].(string)})
+	}
+	slices.SortFunc(slots, func(a, b rotatedSlot) int { return cmp.Compare(a.id, b.id) })
+	return slots
+}
+
+// secretRotationValue reads the rotation value from the config, as it is never stored in the state
+func secretRotationValue(config cty.Value, get func(string) any) (string, bool) {
+	if config.IsNull() {
+		value, ok := get("rotation.0.value").(string)
+		return value, ok && value != ""
+	}
+	if !config.IsKnown() || !config.GetAttr("rotation").IsKnown() {
+		return "", false
+	}
+	rotation := config.GetAttr("rotation")
+	if rotation.IsNull() || rotation.LengthInt() == 0 {
+		return "", false
+	}
+	value := rotation.Index(cty.NumberIntVal(0)).GetAttr("value")
+	if value.IsNull() || !value.IsKnown() {
+		return "", false
+	}
+	return value.AsString(), true
+}
+
+func rotationActiveFrom(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("parsing rotation active_from: %w", err)
+	}
+	return t.Unix(), nil
+}
+
 func secretChecksum(data string) string {
 	hash := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(hash[:])
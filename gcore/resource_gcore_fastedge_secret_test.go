@@ -3,6 +3,7 @@ package gcore
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -359,3 +360,177 @@ func TestFastEdgeSecret_import(t *testing.T) {
 
 	mock.ExpectationsWereMet(t)
 }
+
+func TestFastEdgeSecret_rotation(t *testing.T) {
+	const (
+		jan = 1704067200 // 2024-01-01T00:00:00Z
+		feb = 1706745600 // 2024-02-01T00:00:00Z
+	)
+	janSlot := slotJsonRsp(jan, checksum0)
+	febSlot := slotJsonRsp(feb, checksum1)
+	mock := &mockSDK{
+		t: t,
+		mocks: map[string]*funcMock{
+			"GetSecret": {
+				params: []mockParams{
+					{expectId: 42, retStatus: http.StatusOK, retBody: `{"id": 42, ` + baseSecretJson + janSlot + `]}`},
+					{expectId: 42, retStatus: http.StatusOK, retBody: `{"id": 42, ` + baseSecretJson + janSlot + `]}`},
+					{expectId: 42, retStatus: http.StatusOK, retBody: `{"id": 42, ` + baseSecretJson + janSlot + `,` + febSlot + `]}`},
+					{expectId: 42, retStatus: http.StatusOK, retBody: `{"id": 42, ` + baseSecretJson + janSlot + `,` + febSlot + `]}`},
+					{expectId: 42, retStatus: http.StatusOK, retBody: `{"id": 42, ` + baseSecretJson + febSlot + `]}`},
+				},
+			},
+			"AddSecret": {
+				params: []mockParams{
+					{
+						expectPayload: sdk.AddSecretJSONRequestBody{Secret: sdk.Secret{
+							Name:        ptr("top_secret"),
+							Comment:     ptr("Super-duper secret"),
+							SecretSlots: &[]sdk.SecretSlot{secretSlot(jan, secret0)},
+						}},
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, ` + baseSecretJson + janSlot + `]}`,
+					},
+				},
+			},
+			"PatchSecret": {
+				params: []mockParams{
+					{ // new value added, previous slot kept without sending its value
+						expectId: 42,
+						expectPayload: sdk.PatchSecretJSONRequestBody{
+							Name:        ptr("top_secret"),
+							Comment:     ptr("Super-duper secret"),
+							SecretSlots: &[]sdk.SecretSlot{{Slot: jan}, secretSlot(feb, secret1)},
+						},
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, ` + baseSecretJson + janSlot + `,` + febSlot + `]}`,
+					},
+					{ // previous slot pruned
+						expectId: 42,
+						expectPayload: sdk.PatchSecretJSONRequestBody{
+							Name:        ptr("top_secret"),
+							Comment:     ptr("Super-duper secret"),
+							SecretSlots: &[]sdk.SecretSlot{{Slot: feb}},
+						},
+						retStatus: http.StatusOK,
+						retBody:   `{"id": 42, ` + baseSecretJson + febSlot + `]}`,
+					},
+				},
+			},
+			"DeleteSecret": {
+				params: []mockParams{
+					{
+						expectId:  42,
+						retStatus: http.StatusNoContent,
+					},
+				},
+			},
+		},
+	}
+
+	rotationTf := func(value, activeFrom string, keep int) string {
+		return baseTfFastEdgeSecretConfig + fmt.Sprintf(`
+	rotation {
+		value = "%s"
+		active_from = "%s"
+		keep = %d
+	}
+}`, value, activeFrom, keep)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: fastedgeMockProvider(mock),
+		IsUnitTest:        true,
+		Steps: []resource.TestStep{
+			{ // create resource
+				Config: rotationTf(secret0, "2024-01-01T00:00:00Z", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gcore_fastedge_secret.test", "slot.#", "1"),
+					resource.TestCheckResourceAttr("gcore_fastedge_secret.test", "rotation.0.value", ""),
+				),
+			},
+			{ // rotate value
+				Config: rotationTf(secret1, "2024-02-01T00:00:00Z", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gcore_fastedge_secret.test", "slot.#", "2"),
+					resource.TestCheckResourceAttr("gcore_fastedge_secret.test", "rotation.0.value", ""),
+				),
+			},
+			{ // prune previous slot
+				Config: rotationTf(secret1, "2024-02-01T00:00:00Z", 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("gcore_fastedge_secret.test", "slot.#", "1"),
+					resource.TestCheckResourceAttr("gcore_fastedge_secret.test", "slot.0.id", fmt.Sprint(feb)),
+				),
+			},
+		},
+	})
+
+	mock.ExpectationsWereMet(t)
+}
+
+func TestRotateSecretSlots(t *testing.T) {
+	slots := []rotatedSlot{{100, "a"}, {200, "b"}, {300, "c"}}
+
+	tests := []struct {
+		name       string
+		checksum   string
+		activeFrom int64
+		now        int64
+		keep       int
+		want       []rotatedSlot
+		wantAdded  int64
+	}{
+		{
+			name:      "unchanged",
+			checksum:  "c",
+			now:       350,
+			keep:      5,
+			want:      slots,
+			wantAdded: 0,
+		},
+		{
+			name:      "prune",
+			checksum:  "c",
+			now:       350,
+			keep:      1,
+			want:      []rotatedSlot{{200, "b"}, {300, "c"}},
+			wantAdded: 0,
+		},
+		{
+			name:      "rotate now",
+			checksum:  "d",
+			now:       400,
+			keep:      1,
+			want:      []rotatedSlot{{300, "c"}, {400, "d"}},
+			wantAdded: 400,
+		},
+		{
+			name:       "scheduled",
+			checksum:   "d",
+			activeFrom: 500,
+			now:        400,
+			keep:       0,
+			want:       []rotatedSlot{{300, "c"}, {500, "d"}},
+			wantAdded:  500,
+		},
+		{
+			name:       "replace slot value",
+			checksum:   "d",
+			activeFrom: 300,
+			now:        400,
+			keep:       1,
+			want:       []rotatedSlot{{200, "b"}, {300, "d"}},
+			wantAdded:  300,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, added := rotateSecretSlots(slots, tt.checksum, tt.activeFrom, tt.now, tt.keep)
+			if !slices.Equal(got, tt.want) || added != tt.wantAdded {
+				t.Errorf("got %v (added %d), want %v (added %d)", got, added, tt.want, tt.wantAdded)
+			}
+		})
+	}
+}
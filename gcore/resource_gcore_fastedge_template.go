@@ -148,17 +148,7 @@ func resourceFastEdgeTemplateRead(ctx context.Context, d *schema.ResourceData, m
 	setField(d, "short_descr", template.ShortDescr)
 	setField(d, "long_descr", template.LongDescr)
 	if template.Params != nil {
-		params := make([]any, len(template.Params))
-		for i, param := range template.Params {
-			params[i] = map[string]any{
-				"name":      param.Name,
-				"type":      param.DataType,
-				"mandatory": param.Mandatory,
-				"descr":     param.Descr,
-				"metadata":  param.Metadata,
-			}
-		}
-		d.Set("param", params)
+		d.Set("param", flattenTemplateParams(template.Params))
 	} else {
 		d.Set("param", nil)
 	}
@@ -232,23 +222,45 @@ func resourceFastEdgeTemplateDelete(ctx context.Context, d *schema.ResourceData,
 
 func procParams(d *schema.ResourceData) []sdk.TemplateParam {
 	if v, ok := d.Get("param").(*schema.Set); ok {
-		list := v.List()
-		res := make([]sdk.TemplateParam, len(list))
-		for i, v := range list {
-			p := v.(map[string]any)
-			res[i] = sdk.TemplateParam{
-				Name:      p["name"].(string),
-				DataType:  sdk.TemplateParamDataType(p["type"].(string)),
-				Mandatory: p["mandatory"].(bool),
-				Descr:     getField[string](p, "descr"),
-				Metadata:  getField[string](p, "metadata"),
-			}
-		}
-		return res
+		return expandTemplateParams(v.List())
 	}
 	return []sdk.TemplateParam{}
 }
 
+func expandTemplateParams(list []any) []sdk.TemplateParam {
+	res := make([]sdk.TemplateParam, len(list))
+	for i, v := range list {
+		p := v.(map[string]any)
+		res[i] = sdk.TemplateParam{
+			Name:      p["name"].(string),
+			DataType:  sdk.TemplateParamDataType(p["type"].(string)),
+			Mandatory: p["mandatory"].(bool),
+			Descr:     getField[string](p, "descr"),
+			Metadata:  getField[string](p, "metadata"),
+		}
+	}
+	return res
+}
+
+func flattenTemplateParams(params []sdk.TemplateParam) []any {
+	res := make([]any, len(params))
+	for i, param := range params {
+		p := map[string]any{
+			"name":      param.Name,
+			"type":      string(param.DataType),
+			"mandatory": param.Mandatory,
+		}
+		if param.Descr != nil {
+			p["descr"] = *param.Descr
+		}
+		if param.Metadata != nil {
+			p["metadata"] = *param.Metadata
+		}
+		res[i] = p
+	}
+	return res
+}
+
 func getField[T comparable](p map[string]any, name string) *T {
 	var zero T
 	if v, ok := p[name].(T); ok && v != zero {
@@ -60,6 +60,21 @@ func (m *mockSDK) UpdateApp(ctx context.Context, id int64, body sdk.UpdateAppJSO
 func (m *mockSDK) DelApp(ctx context.Context, id int64, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
 	return m.mock("DelApp", id, nil)
 }
+func (m *mockSDK) ListApps(ctx context.Context, params *sdk.ListAppsParams, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
+	return m.mock("ListApps", 0, *params)
+}
+func (m *mockSDK) GetAppIdByName(ctx context.Context, name string, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
+	return m.mock("GetAppIdByName", 0, name)
+}
+func (m *mockSDK) ListBinaries(ctx context.Context, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
+	return m.mock("ListBinaries", 0, nil)
+}
+func (m *mockSDK) ListTemplates(ctx context.Context, params *sdk.ListTemplatesParams, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
+	return m.mock("ListTemplates", 0, *params)
+}
+func (m *mockSDK) ListSecrets(ctx context.Context, params *sdk.ListSecretsParams, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
+	return m.mock("ListSecrets", 0, *params)
+}
 func (m *mockSDK) GetBinary(ctx context.Context, id int64, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
 	return m.mock("GetBinary", id, nil)
 }
@@ -90,6 +105,9 @@ func (m *mockSDK) AddSecret(ctx context.Context, body sdk.AddSecretJSONRequestBo
 func (m *mockSDK) UpdateSecret(ctx context.Context, id int64, body sdk.UpdateSecretJSONRequestBody, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
 	return m.mock("UpdateSecret", id, body)
 }
+func (m *mockSDK) PatchSecret(ctx context.Context, id int64, body sdk.PatchSecretJSONRequestBody, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
+	return m.mock("PatchSecret", id, body)
+}
 func (m *mockSDK) DeleteSecret(ctx context.Context, id int64, params *sdk.DeleteSecretParams, reqEditors ...sdk.RequestEditorFn) (*http.Response, error) {
 	return m.mock("DeleteSecret", id, nil)
 }
@@ -103,10 +121,6 @@ func (m mockSDK) ExpectationsWereMet(t *testing.T) {
 	}
 }
 
-func ptr[T any](val T) *T {
-	return &val
-}
-
 func compare(t *testing.T, actual, expected any, name string, run int) {
 	t.Helper()
 	if !cmp.Equal(actual, expected) {
@@ -26,6 +26,7 @@ func resourceFileShare() *schema.Resource {
 		ReadContext:   resourceFileShareRead,
 		UpdateContext: resourceFileShareUpdate,
 		DeleteContext: resourceFileShareDelete,
+		CustomizeDiff: validateFileShareSize,
 		Description:   "Represents a file share (NFS) in Gcore Cloud.",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -89,7 +90,19 @@ func resourceFileShare() *schema.Resource {
 			"size": {
 				Type:        schema.TypeInt,
 				Required:    true,
-				Description: `The size of the file share in GB. It must be a positive integer.`,
+				Description: `The size of the file share in GB. It must be a positive integer. The size can only be increased.`,
+			},
+			"snapshot_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the file share snapshot to restore the new file share from.",
+			},
+			"revert_to_snapshot_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The ID of the file share snapshot to revert the file share to. " +
+					"The file share is reverted in place whenever the value changes. Only the latest snapshot can be reverted to.",
 			},
 			"type_name": {
 				Type:        schema.TypeString,
@@ -140,6 +153,8 @@ func resourceFileShare() *schema.Resource {
 			"access": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Description: "Access rules of the file share. Rules managed by `gcore_file_share_access_rule` are kept on update, " +
+					"do not declare the same rule in both places.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"ip_address": {
@@ -276,7 +291,10 @@ func resourceFileShareCreate(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	result := file_shares.Create(client, createOpts)
+	result := file_shares.Create(client, fileShareCreateOpts{
+		CreateOpts: *createOpts,
+		SnapshotID: d.Get("snapshot_id").(string),
+	})
 	if result.Err != nil {
 		return diag.FromErr(result.Err)
 	}
@@ -486,35 +504,59 @@ func resourceFileShareUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		}
 	}
 
-	// Handle access rules update: remove all and re-create if changed
+	if d.HasChange("revert_to_snapshot_id") {
+		if snapshotID := d.Get("revert_to_snapshot_id").(string); snapshotID != "" {
+			log.Printf("[DEBUG] Reverting file share %s to snapshot %s", fileShareID, snapshotID)
+			result := revertFileShare(clientV1, fileShareID, snapshotID)
+			if result.Err != nil {
+				return diag.FromErr(fmt.Errorf("failed to revert file share %s to snapshot %s: %w", fileShareID, snapshotID, result.Err))
+			}
+			taskResults, err := result.Extract()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if len(taskResults.Tasks) == 0 {
+				return diag.FromErr(errors.New("no task IDs returned"))
+			}
+			taskID := taskResults.Tasks[0]
+
+			if err := tasks.WaitForFinishedTask(clientV1, taskID, fileShareCreatingTimeout); err != nil {
+				return diag.FromErr(fmt.Errorf("error while waiting for task %s to finish: %w", taskID, err))
+			}
+		}
+	}
+
+	// Handle access rules update: only touch the rules removed from or added to the config,
+	// so rules managed by gcore_file_share_access_rule are kept
 	if d.HasChange("access") {
 		log.Println("[DEBUG] Updating access rules for file share")
-		// List all current access rules
-		pager := file_shares.ListAccessRules(clientV1, fileShareID)
-		pages, err := pager.AllPages()
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to get all access rule pages for file share %s: %w", fileShareID, err))
-		}
-		rules, err := file_shares.ExtractAccessRule(pages)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to extract access rules for file share %s: %w", fileShareID, err))
-		}
-		// Delete all existing access rules
-		for _, rule := range rules {
-			result := file_shares.DeleteAccessRule(clientV1, fileShareID, rule.ID)
+		oldAccess, newAccess := d.GetChange("access")
+		oldRules := fileShareAccessSet(oldAccess.([]interface{}))
+		newRules := fileShareAccessSet(newAccess.([]interface{}))
+
+		for key, rule := range oldRules {
+			if _, ok := newRules[key]; ok {
+				continue
+			}
+			existing, err := findFileShareAccessRule(clientV1, fileShareID, func(r file_shares.AccessRule) bool {
+				return r.AccessTo == rule.IPAddress && r.AccessLevel == rule.AccessMode
+			})
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to get access rules for file share %s: %w", fileShareID, err))
+			}
+			if existing == nil {
+				continue
+			}
+			result := file_shares.DeleteAccessRule(clientV1, fileShareID, existing.ID)
 			if result.Err != nil {
-				return diag.FromErr(fmt.Errorf("failed to delete access rule %s: %w", rule.ID, result.Err))
+				return diag.FromErr(fmt.Errorf("failed to delete access rule %s: %w", existing.ID, result.Err))
 			}
 		}
-		// Add new access rules from config
-		accessList := d.Get("access").([]interface{})
-		for _, a := range accessList {
-			amap := a.(map[string]interface{})
-			createOpts := file_shares.CreateAccessRuleOpts{
-				IPAddress:  amap["ip_address"].(string),
-				AccessMode: amap["access_mode"].(string),
+		for key, rule := range newRules {
+			if _, ok := oldRules[key]; ok {
+				continue
 			}
-			result := file_shares.CreateAccessRule(clientV1, fileShareID, createOpts)
+			result := file_shares.CreateAccessRule(clientV1, fileShareID, rule)
 			if result.Err != nil {
 				return diag.FromErr(fmt.Errorf("failed to create access rule for file share %s: %w", fileShareID, result.Err))
 			}
@@ -655,3 +697,31 @@ func expandFileShareCreateOpts(d *schema.ResourceData) (*file_shares.CreateOpts,
 
 	return &opts, nil
 }
+
+// fileShareAccessSet indexes the access blocks by ip address and access mode
+func fileShareAccessSet(accessList []interface{}) map[string]file_shares.CreateAccessRuleOpts {
+	rules := make(map[string]file_shares.CreateAccessRuleOpts, len(accessList))
+	for _, a := range accessList {
+		amap := a.(map[string]interface{})
+		rule := file_shares.CreateAccessRuleOpts{
+			IPAddress:  amap["ip_address"].(string),
+			AccessMode: amap["access_mode"].(string),
+		}
+		rules[rule.IPAddress+"/"+rule.AccessMode] = rule
+	}
+	return rules
+}
+
+// validateFileShareSize fails the plan when the size of an existing file share is reduced,
+// file shares can only be extended
+func validateFileShareSize(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("size") || diff.HasChange("snapshot_id") {
+		return nil
+	}
+	oldSize, newSize := diff.GetChange("size")
+	if newSize.(int) < oldSize.(int) {
+		return fmt.Errorf("file share size cannot be reduced from %d GB to %d GB, file shares can only be extended. "+
+			"Restore a snapshot into a new file share to move data to a smaller one", oldSize.(int), newSize.(int))
+	}
+	return nil
+}
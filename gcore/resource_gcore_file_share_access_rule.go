@@ -0,0 +1,176 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/file_share/v1/file_shares"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceFileShareAccessRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFileShareAccessRuleCreate,
+		ReadContext:   resourceFileShareAccessRuleRead,
+		DeleteContext: resourceFileShareAccessRuleDelete,
+		Description: "Represents an access rule of a file share (NFS) in Gcore Cloud. " +
+			"It allows managing access to a file share independently of the `gcore_file_share` resource.",
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, ruleID, fileShareID, err := ImportStringParserExtended(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("file_share_id", fileShareID)
+				d.SetId(ruleID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Project ID, only one of project_id or project_name should be set",
+				ExactlyOneOf:     []string{"project_id", "project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Region ID, only one of region_id or region_name should be set",
+				ExactlyOneOf:     []string{"region_id", "region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Project name, only one of project_id or project_name should be set",
+				ExactlyOneOf: []string{"project_id", "project_name"},
+			},
+			"region_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Region name, only one of region_id or region_name should be set",
+				ExactlyOneOf: []string{"region_id", "region_name"},
+			},
+			"file_share_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the file share.",
+			},
+			"ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR),
+				Description:  "The IP address or CIDR allowed to access the file share.",
+			},
+			"access_mode": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ro", "rw"}, false),
+				Description:  "The access mode of the file share (ro/rw).",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the access rule.",
+			},
+		},
+	}
+}
+
+func resourceFileShareAccessRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start file share access rule creating")
+	config := m.(*Config)
+	provider := config.Provider
+	fileShareID := d.Get("file_share_id").(string)
+
+	client, err := CreateClient(provider, d, fileSharePoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := file_shares.CreateAccessRuleOpts{
+		IPAddress:  d.Get("ip_address").(string),
+		AccessMode: d.Get("access_mode").(string),
+	}
+	rule, err := file_shares.CreateAccessRule(client, fileShareID, opts).Extract()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create access rule for file share %s: %w", fileShareID, err))
+	}
+	d.SetId(rule.ID)
+	log.Printf("[DEBUG] Finish file share access rule creating (%s)", d.Id())
+	return resourceFileShareAccessRuleRead(ctx, d, m)
+}
+
+func resourceFileShareAccessRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start file share access rule reading")
+	config := m.(*Config)
+	provider := config.Provider
+	fileShareID := d.Get("file_share_id").(string)
+	ruleID := d.Id()
+
+	client, err := CreateClient(provider, d, fileSharePoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err := findFileShareAccessRule(client, fileShareID, func(r file_shares.AccessRule) bool {
+		return r.ID == ruleID
+	})
+	if err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if errors.As(err, &errDefault404) {
+			// the file share doesn't exist anymore
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("cannot get access rules of file share with ID: %s. Error: %s", fileShareID, err)
+	}
+	if rule == nil {
+		log.Printf("[WARN] access rule %s not found in file share %s, removing from state", ruleID, fileShareID)
+		d.SetId("")
+		return nil
+	}
+	d.Set("ip_address", rule.AccessTo)
+	d.Set("access_mode", rule.AccessLevel)
+	d.Set("state", rule.State)
+	return nil
+}
+
+func resourceFileShareAccessRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start file share access rule deleting")
+	config := m.(*Config)
+	provider := config.Provider
+	fileShareID := d.Get("file_share_id").(string)
+
+	client, err := CreateClient(provider, d, fileSharePoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := file_shares.DeleteAccessRule(client, fileShareID, d.Id())
+	if result.Err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if !errors.As(result.Err, &errDefault404) {
+			return diag.FromErr(fmt.Errorf("failed to delete access rule %s: %w", d.Id(), result.Err))
+		}
+	}
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of file share access rule deleting")
+	return nil
+}
@@ -0,0 +1,244 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceFileShareSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFileShareSnapshotCreate,
+		ReadContext:   resourceFileShareSnapshotRead,
+		UpdateContext: resourceFileShareSnapshotUpdate,
+		DeleteContext: resourceFileShareSnapshotDelete,
+		Description: "Represents a snapshot of a file share (NFS) in Gcore Cloud. " +
+			"Use `snapshot_id` of `gcore_file_share` to restore a snapshot into a new file share, " +
+			"or `revert_to_snapshot_id` to revert the file share in place.",
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, snapshotID, fileShareID, err := ImportStringParserExtended(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("file_share_id", fileShareID)
+				d.SetId(snapshotID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Project ID, only one of project_id or project_name should be set",
+				ExactlyOneOf:     []string{"project_id", "project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Region ID, only one of region_id or region_name should be set",
+				ExactlyOneOf:     []string{"region_id", "region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Project name, only one of project_id or project_name should be set",
+				ExactlyOneOf: []string{"project_id", "project_name"},
+			},
+			"region_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Region name, only one of region_id or region_name should be set",
+				ExactlyOneOf: []string{"region_id", "region_name"},
+			},
+			"file_share_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the file share to take the snapshot of.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the snapshot.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the snapshot.",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the snapshot in GB.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the snapshot.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the snapshot in ISO 8601 format.",
+			},
+		},
+	}
+}
+
+func resourceFileShareSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start file share snapshot creating")
+	config := m.(*Config)
+	provider := config.Provider
+	fileShareID := d.Get("file_share_id").(string)
+
+	client, err := CreateClient(provider, d, fileSharePoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := createFileShareSnapshot(client, fileShareID, fileShareSnapshotOpts{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	})
+	if result.Err != nil {
+		return diag.FromErr(result.Err)
+	}
+	taskResults, err := result.Extract()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(taskResults.Tasks) == 0 {
+		return diag.FromErr(errors.New("no task IDs returned"))
+	}
+	taskID := taskResults.Tasks[0]
+
+	snapshotID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, fileShareCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(client, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return extractFileShareSnapshotIDFromTask(taskInfo)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(snapshotID.(string))
+	log.Printf("[DEBUG] Finish file share snapshot creating (%s)", d.Id())
+	return resourceFileShareSnapshotRead(ctx, d, m)
+}
+
+func resourceFileShareSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start file share snapshot reading")
+	config := m.(*Config)
+	provider := config.Provider
+	fileShareID := d.Get("file_share_id").(string)
+
+	client, err := CreateClient(provider, d, fileSharePoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshot, err := getFileShareSnapshot(client, fileShareID, d.Id())
+	if err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if errors.As(err, &errDefault404) {
+			// removing from state because it doesn't exist anymore
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("cannot get file share snapshot with ID: %s. Error: %s", d.Id(), err)
+	}
+	d.Set("name", snapshot.Name)
+	d.Set("description", snapshot.Description)
+	d.Set("size", snapshot.Size)
+	d.Set("status", snapshot.Status)
+	d.Set("created_at", snapshot.CreatedAt.String())
+	return nil
+}
+
+func resourceFileShareSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start file share snapshot updating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, fileSharePoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "description") {
+		opts := fileShareSnapshotOpts{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		}
+		if err := updateFileShareSnapshot(client, d.Get("file_share_id").(string), d.Id(), opts); err != nil {
+			return diag.Errorf("cannot update file share snapshot with ID: %s. Error: %s", d.Id(), err)
+		}
+	}
+	return resourceFileShareSnapshotRead(ctx, d, m)
+}
+
+func resourceFileShareSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start file share snapshot deleting")
+	config := m.(*Config)
+	provider := config.Provider
+	fileShareID := d.Get("file_share_id").(string)
+	snapshotID := d.Id()
+
+	client, err := CreateClient(provider, d, fileSharePoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := deleteFileShareSnapshot(client, fileShareID, snapshotID)
+	if result.Err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if errors.As(result.Err, &errDefault404) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(result.Err)
+	}
+	taskResults, err := result.Extract()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(taskResults.Tasks) == 0 {
+		return diag.FromErr(errors.New("no task IDs returned"))
+	}
+	taskID := taskResults.Tasks[0]
+
+	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, fileShareDeletingTimeout, func(task tasks.TaskID) (interface{}, error) {
+		_, err := getFileShareSnapshot(client, fileShareID, snapshotID)
+		if err == nil {
+			return nil, fmt.Errorf("cannot delete file share snapshot with ID: %s", snapshotID)
+		}
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of file share snapshot deleting")
+	return nil
+}
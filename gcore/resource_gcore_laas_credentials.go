@@ -0,0 +1,166 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceLaaSCredentials() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLaaSCredentialsCreate,
+		ReadContext:   resourceLaaSCredentialsRead,
+		UpdateContext: resourceLaaSCredentialsUpdate,
+		DeleteContext: resourceLaaSCredentialsDelete,
+		CustomizeDiff: resourceLaaSCredentialsCustomizeDiff,
+		Description: "Represent LaaS Kafka credentials. A new password is generated on creation and when `rotation_triggers` change, " +
+			"the previous password stops working. Deleting the resource keeps the current password valid",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"rotation_triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values, a new password is generated when any of them changes",
+			},
+			"username": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"kafka_hosts": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// plan password generation when triggers change
+func resourceLaaSCredentialsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() != "" && diff.HasChange("rotation_triggers") {
+		return diff.SetNewComputed("password")
+	}
+	return nil
+}
+
+func resourceLaaSCredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start LaaS credentials creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	projectID, regionID, err := getProjectAndRegionID(provider, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	client, err := CreateClient(provider, d, laasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	user, err := regenerateLaaSUser(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%d:%d", projectID, regionID))
+	d.Set("username", user.Username)
+	d.Set("password", user.Password)
+
+	log.Printf("[DEBUG] Finish LaaS credentials creating (%s)", d.Id())
+	return resourceLaaSCredentialsRead(ctx, d, m)
+}
+
+func resourceLaaSCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start LaaS credentials reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, laasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the password is only returned on generation, keep the known one
+	hosts, err := laas.ListKafkaHosts(client).Extract()
+	if err != nil {
+		return diag.Errorf("cannot get LaaS kafka hosts. Error: %s", err)
+	}
+	d.Set("kafka_hosts", *hosts)
+
+	log.Println("[DEBUG] Finish LaaS credentials reading")
+	return nil
+}
+
+func resourceLaaSCredentialsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start LaaS credentials updating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	if d.HasChange("rotation_triggers") {
+		client, err := CreateClient(provider, d, laasPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		user, err := regenerateLaaSUser(client)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("username", user.Username)
+		d.Set("password", user.Password)
+	}
+
+	log.Println("[DEBUG] Finish LaaS credentials updating")
+	return resourceLaaSCredentialsRead(ctx, d, m)
+}
+
+func resourceLaaSCredentialsDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] LaaS credentials %s removed from state, password stays valid", d.Id())
+	d.SetId("")
+	return nil
+}
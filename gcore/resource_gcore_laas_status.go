@@ -0,0 +1,154 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceLaaSStatus() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLaaSStatusCreate,
+		ReadContext:   resourceLaaSStatusRead,
+		UpdateContext: resourceLaaSStatusUpdate,
+		DeleteContext: resourceLaaSStatusDelete,
+		Description:   "Represent LaaS status in a region. Use to enable or disable LaaS, LaaS is disabled when the resource is deleted",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"is_initialized": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether LaaS is enabled in the region",
+			},
+			"namespace": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLaaSStatusCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start LaaS status creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	projectID, regionID, err := getProjectAndRegionID(provider, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	client, err := CreateClient(provider, d, laasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := laas.UpdateStatusOpts{IsInitialized: d.Get("is_initialized").(bool)}
+	if _, err := laas.UpdateStatus(client, opts).Extract(); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%d:%d", projectID, regionID))
+
+	log.Printf("[DEBUG] Finish LaaS status creating (%s)", d.Id())
+	return resourceLaaSStatusRead(ctx, d, m)
+}
+
+func resourceLaaSStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start LaaS status reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, laasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	status, err := laas.GetStatus(client).Extract()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("namespace", status.Namespace)
+	d.Set("is_initialized", status.IsInitialized)
+
+	log.Println("[DEBUG] Finish LaaS status reading")
+	return nil
+}
+
+func resourceLaaSStatusUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start LaaS status updating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	if d.HasChange("is_initialized") {
+		client, err := CreateClient(provider, d, laasPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		opts := laas.UpdateStatusOpts{IsInitialized: d.Get("is_initialized").(bool)}
+		if _, err := laas.UpdateStatus(client, opts).Extract(); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Println("[DEBUG] Finish LaaS status updating")
+	return resourceLaaSStatusRead(ctx, d, m)
+}
+
+func resourceLaaSStatusDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start LaaS status deleting")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, laasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := laas.UpdateStatus(client, laas.UpdateStatusOpts{IsInitialized: false}).Extract(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish of LaaS status deleting")
+	return nil
+}
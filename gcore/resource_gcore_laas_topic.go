@@ -2,11 +2,13 @@ package gcore
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const laasPoint = "laas"
@@ -15,7 +17,9 @@ func resourceLaaSTopic() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceLaaSTopicCreate,
 		ReadContext:   resourceLaaSTopicRead,
+		UpdateContext: resourceLaaSTopicUpdate,
 		DeleteContext: resourceLaaSTopicDelete,
+		CustomizeDiff: resourceLaaSTopicCustomizeDiff,
 		Description:   "Represent LaaS topic",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -76,6 +80,20 @@ func resourceLaaSTopic() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"partitions": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of partitions of the topic. The number can only be increased",
+			},
+			"retention_ms": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.Any(validation.IntInSlice([]int{-1}), validation.IntAtLeast(1)),
+				Description:  "How long messages are kept in the topic in milliseconds, -1 keeps them forever",
+			},
 		},
 	}
 }
@@ -91,7 +109,11 @@ func resourceLaaSTopicCreate(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
-	opts := laas.CreateTopicOpts{Name: d.Get("name").(string)}
+	opts := laasTopicCreateOpts{
+		CreateTopicOpts: laas.CreateTopicOpts{Name: d.Get("name").(string)},
+		Partitions:      d.Get("partitions").(int),
+		RetentionMs:     d.Get("retention_ms").(int),
+	}
 	topic, err := laas.CreateTopic(client, opts).Extract()
 	if err != nil {
 		return diag.FromErr(err)
@@ -117,26 +139,49 @@ func resourceLaaSTopicRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
-	topics, err := laas.ListTopicAll(client)
+	topic, err := getLaaSTopic(client, topicName)
 	if err != nil {
 		return diag.Errorf("cannot get topic's list. Error: %s", err.Error())
 	}
-	var topic laas.Topic
-	for _, t := range topics {
-		if t.Name == topicName {
-			topic = t
-			break
-		}
-	}
-	if topic.Name == "" {
+	if topic == nil {
 		return diag.Errorf("cant find topic with name %s", topicName)
 	}
 	d.Set("name", topic.Name)
+	d.Set("partitions", topic.Partitions)
+	d.Set("retention_ms", topic.RetentionMs)
 
 	log.Println("[DEBUG] Finish LaaS topic reading")
 	return diags
 }
 
+func resourceLaaSTopicUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start LaaS topic updating")
+	config := m.(*Config)
+	provider := config.Provider
+	topicName := d.Id()
+
+	client, err := CreateClient(provider, d, laasPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var opts laasTopicUpdateOpts
+	if d.HasChange("partitions") {
+		opts.Partitions = d.Get("partitions").(int)
+	}
+	if d.HasChange("retention_ms") {
+		opts.RetentionMs = d.Get("retention_ms").(int)
+	}
+	if opts != (laasTopicUpdateOpts{}) {
+		if err := updateLaaSTopic(client, topicName, opts); err != nil {
+			return diag.Errorf("cannot update topic %s. Error: %s", topicName, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finish LaaS topic updating (%s)", topicName)
+	return resourceLaaSTopicRead(ctx, d, m)
+}
+
 func resourceLaaSTopicDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start LaaS topic deleting")
 	var diags diag.Diagnostics
@@ -158,3 +203,15 @@ func resourceLaaSTopicDelete(ctx context.Context, d *schema.ResourceData, m inte
 	log.Printf("[DEBUG] Finish of LaaS topic deleting")
 	return diags
 }
+
+func resourceLaaSTopicCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("partitions") {
+		return nil
+	}
+	oldPartitions, newPartitions := diff.GetChange("partitions")
+	if newPartitions.(int) < oldPartitions.(int) {
+		return fmt.Errorf("topic partitions cannot be reduced from %d to %d, Kafka only supports adding partitions",
+			oldPartitions.(int), newPartitions.(int))
+	}
+	return nil
+}
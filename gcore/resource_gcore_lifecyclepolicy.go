@@ -30,7 +30,8 @@ func resourceLifecyclePolicy() *schema.Resource {
 		ReadContext:   resourceLifecyclePolicyRead,
 		UpdateContext: resourceLifecyclePolicyUpdate,
 		DeleteContext: resourceLifecyclePolicyDelete,
-		Description:   "Represent lifecycle policy. Use to periodically take snapshots",
+		CustomizeDiff: validateLifecyclePolicyTargets,
+		Description:   "Represent lifecycle policy. Use to periodically take snapshots of volumes or backups of instances",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, lcpID, err := ImportStringParser(d.Id())
@@ -89,12 +90,14 @@ func resourceLifecyclePolicy() *schema.Resource {
 				Optional:     true,
 				Default:      lifecyclepolicy.PolicyActionVolumeSnapshot.String(),
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice(lifecyclepolicy.PolicyAction("").StringList(), false),
+				ValidateFunc: validation.StringInSlice(lifecyclePolicyActions, false),
+				Description: "Available values are 'volume_snapshot' to take snapshots of volumes and 'instance_backup' " +
+					"to take consistent snapshots of all volumes attached to instances",
 			},
 			"volume": {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "List of managed volumes",
+				Description: "List of managed volumes, only for 'volume_snapshot' action",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -109,6 +112,50 @@ func resourceLifecyclePolicy() *schema.Resource {
 					},
 				},
 			},
+			"volume_metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Volumes having all of these metadata tags are managed by the policy, including volumes created later. Only for 'volume_snapshot' action",
+			},
+			"instance": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "List of managed instances, only for 'instance_backup' action",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"cross_region_copy": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Copy the snapshots taken by the policy to other regions",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Region to copy the snapshots to",
+						},
+						"max_quantity": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(1, 10000),
+							Description:  "Maximum number of stored copies in the region, max_quantity of the schedule is used if not set",
+						},
+					},
+				},
+			},
 			"schedule": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -279,7 +326,7 @@ func resourceLifecyclePolicyCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	policy, err := lifecyclepolicy.Create(client, *opts).Extract()
+	policy, err := createLifecyclePolicy(client, *opts).Extract()
 	if err != nil {
 		return diag.Errorf("Error creating lifecycle policy: %s", err)
 	}
@@ -300,10 +347,15 @@ func resourceLifecyclePolicyRead(_ context.Context, d *schema.ResourceData, m in
 	}
 
 	log.Printf("[DEBUG] Start of LifecyclePolicy %s reading", id)
-	policy, err := lifecyclepolicy.Get(client, integerId, lifecyclepolicy.GetOpts{NeedVolumes: true}).Extract()
+	result := lifecyclepolicy.Get(client, integerId, lifecyclepolicy.GetOpts{NeedVolumes: true})
+	policy, err := result.Extract()
 	if err != nil {
 		return diag.Errorf("Error getting lifecycle policy: %s", err)
 	}
+	var extra lifecyclePolicyExtra
+	if err := result.ExtractInto(&extra); err != nil {
+		return diag.Errorf("Error getting lifecycle policy: %s", err)
+	}
 
 	_ = d.Set("name", policy.Name)
 	_ = d.Set("status", policy.Status)
@@ -315,6 +367,17 @@ func resourceLifecyclePolicyRead(_ context.Context, d *schema.ResourceData, m in
 	if err = d.Set("schedule", flattenSchedules(policy.Schedules)); err != nil {
 		return diag.Errorf("error setting lifecycle policy schedules: %s", err)
 	}
+	instances := make([]map[string]string, len(extra.Instances))
+	for i, instance := range extra.Instances {
+		instances[i] = map[string]string{"id": instance.ID, "name": instance.Name}
+	}
+	if err = d.Set("instance", instances); err != nil {
+		return diag.Errorf("error setting lifecycle policy instances: %s", err)
+	}
+	_ = d.Set("volume_metadata", extra.VolumeMetadata)
+	if err = d.Set("cross_region_copy", flattenLifecyclePolicyCopies(extra.CrossRegionCopy)); err != nil {
+		return diag.Errorf("error setting lifecycle policy cross region copy: %s", err)
+	}
 
 	log.Printf("[DEBUG] Finish of LifecyclePolicy %s reading", id)
 	return nil
@@ -332,14 +395,14 @@ func resourceLifecyclePolicyUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	log.Printf("[DEBUG] Start of LifecyclePolicy updating")
-	_, err = lifecyclepolicy.Update(client, integerId, buildLifecyclePolicyUpdateOpts(d)).Extract()
+	_, err = updateLifecyclePolicy(client, integerId, buildLifecyclePolicyUpdateOpts(d)).Extract()
 	if err != nil {
 		return diag.Errorf("Error updating lifecycle policy: %s", err)
 	}
 
 	if d.HasChange("volume") {
 		oldVolumes, newVolumes := d.GetChange("volume")
-		toRemove, toAdd := volumeSymmetricDifference(oldVolumes.(*schema.Set), newVolumes.(*schema.Set))
+		toRemove, toAdd := setIDSymmetricDifference(oldVolumes.(*schema.Set), newVolumes.(*schema.Set))
 		_, err = lifecyclepolicy.RemoveVolumes(client, integerId, lifecyclepolicy.RemoveVolumesOpts{VolumeIds: toRemove}).Extract()
 		if err != nil {
 			return diag.Errorf("Error removing volumes from lifecycle policy: %s", err)
@@ -349,6 +412,21 @@ func resourceLifecyclePolicyUpdate(ctx context.Context, d *schema.ResourceData,
 			return diag.Errorf("Error adding volumes to lifecycle policy: %s", err)
 		}
 	}
+
+	if d.HasChange("instance") {
+		oldInstances, newInstances := d.GetChange("instance")
+		toRemove, toAdd := setIDSymmetricDifference(oldInstances.(*schema.Set), newInstances.(*schema.Set))
+		if len(toRemove) > 0 {
+			if err = removeLifecyclePolicyInstances(client, integerId, toRemove); err != nil {
+				return diag.Errorf("Error removing instances from lifecycle policy: %s", err)
+			}
+		}
+		if len(toAdd) > 0 {
+			if err = addLifecyclePolicyInstances(client, integerId, toAdd); err != nil {
+				return diag.Errorf("Error adding instances to lifecycle policy: %s", err)
+			}
+		}
+	}
 	log.Printf("[DEBUG] Finish of LifecyclePolicy %v updating", integerId)
 	return resourceLifecyclePolicyRead(ctx, d, m)
 }
@@ -443,7 +521,8 @@ func expandSchedules(flat []interface{}) ([]lifecyclepolicy.CreateScheduleOpts,
 	return expanded, nil
 }
 
-func expandVolumeIds(flat []interface{}) []string {
+// expandSetIDs returns the ids of volume or instance set elements
+func expandSetIDs(flat []interface{}) []string {
 	expanded := make([]string, len(flat))
 	for i, x := range flat {
 		expanded[i] = x.(map[string]interface{})["id"].(string)
@@ -451,16 +530,21 @@ func expandVolumeIds(flat []interface{}) []string {
 	return expanded
 }
 
-func buildLifecyclePolicyCreateOpts(d *schema.ResourceData) (*lifecyclepolicy.CreateOpts, error) {
+func buildLifecyclePolicyCreateOpts(d *schema.ResourceData) (*lifecyclePolicyCreateOpts, error) {
 	schedules, err := expandSchedules(d.Get("schedule").([]interface{}))
 	if err != nil {
 		return nil, err
 	}
-	opts := &lifecyclepolicy.CreateOpts{
-		Name:      d.Get("name").(string),
-		Status:    lifecyclepolicy.PolicyStatus(d.Get("status").(string)),
-		Schedules: schedules,
-		VolumeIds: expandVolumeIds(d.Get("volume").(*schema.Set).List()),
+	opts := &lifecyclePolicyCreateOpts{
+		CreateOpts: lifecyclepolicy.CreateOpts{
+			Name:      d.Get("name").(string),
+			Status:    lifecyclepolicy.PolicyStatus(d.Get("status").(string)),
+			Schedules: schedules,
+			VolumeIds: expandSetIDs(d.Get("volume").(*schema.Set).List()),
+		},
+		InstanceIDs:     expandSetIDs(d.Get("instance").(*schema.Set).List()),
+		VolumeMetadata:  expandLifecyclePolicyVolumeMetadata(d.Get("volume_metadata").(map[string]interface{})),
+		CrossRegionCopy: expandLifecyclePolicyCopies(d.Get("cross_region_copy").([]interface{})),
 	}
 
 	// Action is required field from API point of view, but optional for us
@@ -472,30 +556,98 @@ func buildLifecyclePolicyCreateOpts(d *schema.ResourceData) (*lifecyclepolicy.Cr
 	return opts, nil
 }
 
-func volumeSymmetricDifference(oldVolumes, newVolumes *schema.Set) ([]string, []string) {
+// setIDSymmetricDifference returns the ids of volume or instance set elements to remove and to add
+func setIDSymmetricDifference(oldSet, newSet *schema.Set) ([]string, []string) {
 	toRemove := make([]string, 0)
-	for _, v := range oldVolumes.List() {
-		if !newVolumes.Contains(v) {
+	for _, v := range oldSet.List() {
+		if !newSet.Contains(v) {
 			toRemove = append(toRemove, v.(map[string]interface{})["id"].(string))
 		}
 	}
 	toAdd := make([]string, 0)
-	for _, v := range newVolumes.List() {
-		if !oldVolumes.Contains(v) {
+	for _, v := range newSet.List() {
+		if !oldSet.Contains(v) {
 			toAdd = append(toAdd, v.(map[string]interface{})["id"].(string))
 		}
 	}
 	return toRemove, toAdd
 }
 
-func buildLifecyclePolicyUpdateOpts(d *schema.ResourceData) lifecyclepolicy.UpdateOpts {
-	opts := lifecyclepolicy.UpdateOpts{
-		Name:   d.Get("name").(string),
-		Status: lifecyclepolicy.PolicyStatus(d.Get("status").(string)),
+func buildLifecyclePolicyUpdateOpts(d *schema.ResourceData) lifecyclePolicyUpdateOpts {
+	opts := lifecyclePolicyUpdateOpts{
+		UpdateOpts: lifecyclepolicy.UpdateOpts{
+			Name:   d.Get("name").(string),
+			Status: lifecyclepolicy.PolicyStatus(d.Get("status").(string)),
+		},
+	}
+	if d.HasChange("volume_metadata") {
+		opts.VolumeMetadata = expandLifecyclePolicyVolumeMetadata(d.Get("volume_metadata").(map[string]interface{}))
+	}
+	if d.HasChange("cross_region_copy") {
+		opts.CrossRegionCopy = expandLifecyclePolicyCopies(d.Get("cross_region_copy").([]interface{}))
 	}
 	return opts
 }
 
+func expandLifecyclePolicyVolumeMetadata(flat map[string]interface{}) map[string]string {
+	expanded := make(map[string]string, len(flat))
+	for k, v := range flat {
+		expanded[k] = v.(string)
+	}
+	return expanded
+}
+
+func expandLifecyclePolicyCopies(flat []interface{}) []lifecyclePolicyCopyOpts {
+	expanded := make([]lifecyclePolicyCopyOpts, len(flat))
+	for i, x := range flat {
+		rawCopy := x.(map[string]interface{})
+		expanded[i] = lifecyclePolicyCopyOpts{
+			RegionID:    rawCopy["region_id"].(int),
+			MaxQuantity: rawCopy["max_quantity"].(int),
+		}
+	}
+	return expanded
+}
+
+func flattenLifecyclePolicyCopies(expanded []lifecyclePolicyCopyOpts) []map[string]int {
+	flat := make([]map[string]int, len(expanded))
+	for i, x := range expanded {
+		flat[i] = map[string]int{"region_id": x.RegionID, "max_quantity": x.MaxQuantity}
+	}
+	return flat
+}
+
+// validateLifecyclePolicyTargets checks that volumes are only managed by volume_snapshot policies
+// and instances by instance_backup policies
+func validateLifecyclePolicyTargets(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	action := diff.Get("action").(string)
+	hasVolumes := diff.Get("volume").(*schema.Set).Len() > 0 || len(diff.Get("volume_metadata").(map[string]interface{})) > 0
+	hasInstances := diff.Get("instance").(*schema.Set).Len() > 0
+	switch {
+	case action == lifecyclePolicyActionInstanceBackup && hasVolumes:
+		return fmt.Errorf("volume and volume_metadata can't be used with '%s' action, use instance instead", action)
+	case action != lifecyclePolicyActionInstanceBackup && hasInstances:
+		return fmt.Errorf("instance can only be used with '%s' action", lifecyclePolicyActionInstanceBackup)
+	}
+
+	if !diff.NewValueKnown("region_id") {
+		return nil
+	}
+	regionID := diff.Get("region_id").(int)
+	seen := make(map[int]bool)
+	for _, x := range diff.Get("cross_region_copy").([]interface{}) {
+		copyRegionID := x.(map[string]interface{})["region_id"].(int)
+		if copyRegionID == regionID && regionID != 0 {
+			return fmt.Errorf("cross_region_copy region_id %d must differ from the region of the policy", copyRegionID)
+		}
+		if seen[copyRegionID] {
+			return fmt.Errorf("cross_region_copy region_id %d is set more than once", copyRegionID)
+		}
+		seen[copyRegionID] = true
+	}
+	return nil
+}
+
 func flattenIntervalSchedule(expanded lifecyclepolicy.IntervalSchedule) interface{} {
 	return []map[string]int{{
 		"weeks":   expanded.Weeks,
@@ -1,19 +1,23 @@
 package gcore
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
 	"github.com/G-Core/gcorelabscloud-go/gcore/dbaas/postgres/v1/clusters"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -66,7 +70,7 @@ func resourcePostgresCluster() *schema.Resource {
 			},
 		},
 		Schema:        resourceSchema(),
-		CustomizeDiff: validateDatabaseOwners,
+		CustomizeDiff: customdiff.All(validateDatabaseOwners, validatePostgresNetwork, validatePostgresConfiguration),
 	}
 }
 
@@ -176,8 +180,8 @@ func resourceSchema() map[string]*schema.Schema {
 				Schema: map[string]*schema.Schema{
 					"acl": {
 						Type:        schema.TypeSet,
-						Description: "List of IP addresses or CIDR blocks allowed to access the cluster.",
-						Required:    true,
+						Description: "List of IP addresses or CIDR blocks allowed to access the cluster. Required for `public` networks.",
+						Optional:    true,
 						Elem: &schema.Schema{
 							Type: schema.TypeString,
 						},
@@ -185,10 +189,22 @@ func resourceSchema() map[string]*schema.Schema {
 					},
 					"network_type": {
 						Type:             schema.TypeString,
-						Description:      "Network type. Currently, only `public` is supported.",
+						Description:      "Network type. Possible values are `public` and `private`. A `private` cluster is only reachable from `network_id`.",
 						Optional:         true,
 						Default:          "public",
-						ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"public"}, false)),
+						ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"public", "private"}, false)),
+					},
+					"network_id": {
+						Type:        schema.TypeString,
+						Description: "ID of the `gcore_network` to connect a `private` cluster to.",
+						Optional:    true,
+						ForceNew:    true,
+					},
+					"subnet_id": {
+						Type:        schema.TypeString,
+						Description: "ID of the `gcore_subnet` of `network_id` to allocate cluster addresses from.",
+						Optional:    true,
+						ForceNew:    true,
 					},
 					"connection_string": {
 						Type:        schema.TypeString,
@@ -211,9 +227,10 @@ func resourceSchema() map[string]*schema.Schema {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"version": {
-						Type:        schema.TypeString,
-						Description: "PostgreSQL version. Possible values are `13`, `14`, and `15`.",
-						Required:    true,
+						Type: schema.TypeString,
+						Description: "PostgreSQL version. Possible values are `13`, `14`, and `15`. " +
+							"Changing it upgrades the cluster in place after a compatibility check, downgrades are not supported.",
+						Required: true,
 					},
 					"pg_conf": {
 						Type:        schema.TypeString,
@@ -224,6 +241,13 @@ func resourceSchema() map[string]*schema.Schema {
 							return suppressDiffMultiline(k, old, new, d)
 						},
 					},
+					"parameters": {
+						Type:     schema.TypeMap,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+						Description: "PostgreSQL parameters, e.g. `max_connections = \"200\"`, taking precedence over `pg_conf`. " +
+							"Names, units and ranges are validated at plan time against `gcore_postgres_configuration`.",
+					},
 					"pooler_mode": {
 						Type:             schema.TypeString,
 						Description:      "Connection pooler mode. Possible values are `session`, `transaction`, and `statement`. If not set, connection pooler is not enabled.",
@@ -305,6 +329,126 @@ func resourceSchema() map[string]*schema.Schema {
 				return schema.HashString(m["name"].(string) + "|" + strings.Join(roleAttrsStrs, ","))
 			},
 		},
+		"read_replicas": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Read replicas configuration. Replicas serve read-only queries via `replica_connection_string`.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"count": {
+						Type:             schema.TypeInt,
+						Description:      "Number of read replicas. Must be between 1 and 5.",
+						Required:         true,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 5)),
+					},
+					"flavor": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Computed:    true,
+						MaxItems:    1,
+						Description: "Flavor of the replica instances, the flavor of the cluster if not set.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"cpu": {
+									Type:        schema.TypeInt,
+									Description: "Number of CPU cores.",
+									Required:    true,
+								},
+								"memory": {
+									Type:        schema.TypeInt,
+									Description: "Amount of RAM in GiB.",
+									Required:    true,
+								},
+							},
+						},
+					},
+					"host": {
+						Type:        schema.TypeString,
+						Description: "Host address for the read replicas.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"backup": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Description: "Backup configuration. The default configuration of the region is used if not set.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"schedule": {
+						Type:        schema.TypeString,
+						Description: "Schedule of full backups in cron format, in UTC, e.g. `0 3 * * *`.",
+						Required:    true,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(
+							regexp.MustCompile(`^\S+( \S+){4}$`), "must be a cron expression with 5 fields")),
+					},
+					"retention_days": {
+						Type:             schema.TypeInt,
+						Description:      "Number of days to keep backups. Must be between 1 and 35.",
+						Required:         true,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 35)),
+					},
+					"storage_location": {
+						Type:        schema.TypeString,
+						Description: "Storage location of backups, the location of the region if not set.",
+						Optional:    true,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"restore_from": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "Create the cluster from a backup of another cluster, e.g. to clone it or for disaster recovery drills. Only used on create, changing it recreates the cluster.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cluster_name": {
+						Type:        schema.TypeString,
+						Description: "Name of the source cluster in the same project and region.",
+						Required:    true,
+						ForceNew:    true,
+					},
+					"backup_id": {
+						Type:          schema.TypeString,
+						Description:   "ID of the backup to restore, see `gcore_postgres_backups`. The latest state of the source cluster is restored if neither `backup_id` nor `point_in_time` is set.",
+						Optional:      true,
+						ForceNew:      true,
+						ConflictsWith: []string{"restore_from.0.point_in_time"},
+					},
+					"point_in_time": {
+						Type:             schema.TypeString,
+						Description:      "Moment to restore in RFC 3339 format, e.g. `2024-05-01T10:00:00Z`. Must be within the recovery window of a backup of the source cluster.",
+						Optional:         true,
+						ForceNew:         true,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+						ConflictsWith:    []string{"restore_from.0.backup_id"},
+					},
+				},
+			},
+		},
+		"primary_connection_string": {
+			Type:        schema.TypeString,
+			Description: "Connection string for the primary instance, for read-write queries.",
+			Computed:    true,
+		},
+		"replica_connection_string": {
+			Type:        schema.TypeString,
+			Description: "Connection string for the read replicas, for read-only queries. Empty without `read_replicas`.",
+			Computed:    true,
+		},
+		"restart_required_parameters": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Changed parameters that take effect after a cluster restart, including the ones of the planned change.",
+			Computed:    true,
+		},
 		"status": {
 			Type:        schema.TypeString,
 			Description: "Current status of the cluster.",
@@ -345,8 +489,8 @@ func resourcePostgresClusterCreate(ctx context.Context, data *schema.ResourceDat
 	return resourcePostgresClusterRead(ctx, data, m)
 }
 
-func expandClusterCreateOpts(data *schema.ResourceData) (*clusters.CreateOpts, error) {
-	opts := clusters.CreateOpts{ClusterName: data.Get("name").(string)}
+func expandClusterCreateOpts(data *schema.ResourceData) (*postgresClusterCreateOpts, error) {
+	opts := postgresClusterCreateOpts{CreateOpts: clusters.CreateOpts{ClusterName: data.Get("name").(string)}}
 
 	// extract flavors
 	flavorList := data.Get("flavor").([]interface{})
@@ -377,26 +521,20 @@ func expandClusterCreateOpts(data *schema.ResourceData) (*clusters.CreateOpts, e
 	// extract network
 	networkList := data.Get("network").([]interface{})
 	if len(networkList) > 0 {
-		networkMap := networkList[0].(map[string]interface{})
-		aclSet := networkMap["acl"].(*schema.Set)
-		acl := make([]string, 0)
-		for _, v := range aclSet.List() {
-			acl = append(acl, v.(string))
-		}
-		opts.Network = clusters.NetworkOpts{
-			ACL:         acl,
-			NetworkType: networkMap["network_type"].(string),
-		}
+		opts.Network = expandPostgresNetworkOpts(networkList[0].(map[string]interface{}))
 	} else {
 		return nil, fmt.Errorf("network must be specified")
 	}
 
+	// extract read replicas
+	opts.ReadReplicas = expandPostgresReadReplicasOpts(data.Get("read_replicas").([]interface{}))
+
 	// extract pg_config
 	pgConfigList := data.Get("pg_config").([]interface{})
 	if len(pgConfigList) > 0 {
 		pgConfigMap := pgConfigList[0].(map[string]interface{})
 		opts.PGServerConfiguration = clusters.PGServerConfigurationOpts{
-			PGConf:  pgConfigMap["pg_conf"].(string),
+			PGConf:  postgresPGConf(pgConfigMap),
 			Version: pgConfigMap["version"].(string),
 		}
 		if poolerMode, ok := pgConfigMap["pooler_mode"].(string); ok && poolerMode != "" {
@@ -446,9 +584,109 @@ func expandClusterCreateOpts(data *schema.ResourceData) (*clusters.CreateOpts, e
 		}
 	}
 
+	// extract backup and restore source
+	opts.Backup = expandPostgresBackupOpts(data.Get("backup").([]interface{}))
+	if restoreList := data.Get("restore_from").([]interface{}); len(restoreList) > 0 {
+		restoreMap := restoreList[0].(map[string]interface{})
+		opts.RestoreFrom = &postgresRestoreOpts{
+			ClusterName: restoreMap["cluster_name"].(string),
+			BackupID:    restoreMap["backup_id"].(string),
+			PointInTime: restoreMap["point_in_time"].(string),
+		}
+	}
+
 	return &opts, nil
 }
 
+// postgresPGConf merges parameters into pg_conf, parameters take precedence
+func postgresPGConf(pgConfigMap map[string]interface{}) string {
+	pgConf := pgConfigMap["pg_conf"].(string)
+	parameters, _ := pgConfigMap["parameters"].(map[string]interface{})
+	if len(parameters) == 0 {
+		return pgConf
+	}
+	conf := parsePGConf(pgConf)
+	for key, value := range parameters {
+		conf[key] = value.(string)
+	}
+	return renderPGConf(conf)
+}
+
+// splitPGConf moves configured parameters from the cluster pg_conf to parameters,
+// pg_conf lines overridden by parameters keep their configured value
+func splitPGConf(clusterConf string, parameters map[string]interface{}, configuredConf string) (string, map[string]interface{}) {
+	if len(parameters) == 0 {
+		return clusterConf, nil
+	}
+	conf := parsePGConf(clusterConf)
+	configured := parsePGConf(configuredConf)
+	result := make(map[string]interface{}, len(parameters))
+	for key := range parameters {
+		if value, ok := conf[key]; ok {
+			result[key] = value
+		}
+		if value, ok := configured[key]; ok {
+			conf[key] = value
+		} else {
+			delete(conf, key)
+		}
+	}
+	return renderPGConf(conf), result
+}
+
+func expandPostgresNetworkOpts(networkMap map[string]interface{}) *postgresNetworkOpts {
+	acl := make([]string, 0)
+	for _, v := range networkMap["acl"].(*schema.Set).List() {
+		acl = append(acl, v.(string))
+	}
+	return &postgresNetworkOpts{
+		ACL:         acl,
+		NetworkType: networkMap["network_type"].(string),
+		NetworkID:   networkMap["network_id"].(string),
+		SubnetID:    networkMap["subnet_id"].(string),
+	}
+}
+
+// expandPostgresReadReplicasOpts returns nil without replicas, the replica flavor is omitted to use the cluster one
+func expandPostgresReadReplicasOpts(replicasList []interface{}) *postgresReadReplicasOpts {
+	if len(replicasList) == 0 || replicasList[0] == nil {
+		return nil
+	}
+	replicasMap := replicasList[0].(map[string]interface{})
+	opts := &postgresReadReplicasOpts{Count: replicasMap["count"].(int)}
+	if flavorList := replicasMap["flavor"].([]interface{}); len(flavorList) > 0 && flavorList[0] != nil {
+		flavorMap := flavorList[0].(map[string]interface{})
+		opts.Flavor = &clusters.FlavorOpts{
+			CPU:       flavorMap["cpu"].(int),
+			MemoryGiB: flavorMap["memory"].(int),
+		}
+	}
+	return opts
+}
+
+func expandPostgresBackupOpts(backupList []interface{}) *postgresBackupOpts {
+	if len(backupList) == 0 || backupList[0] == nil {
+		return nil
+	}
+	backupMap := backupList[0].(map[string]interface{})
+	return &postgresBackupOpts{
+		Schedule:        backupMap["schedule"].(string),
+		RetentionDays:   backupMap["retention_days"].(int),
+		StorageLocation: backupMap["storage_location"].(string),
+	}
+}
+
+func flattenPostgresBackupOpts(backup *postgresBackupOpts) []interface{} {
+	if backup == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"schedule":         backup.Schedule,
+		"retention_days":   backup.RetentionDays,
+		"storage_location": backup.StorageLocation,
+	}}
+}
+
 func resourcePostgresClusterRead(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start postgres cluster read")
 	config := m.(*Config)
@@ -459,7 +697,8 @@ func resourcePostgresClusterRead(ctx context.Context, data *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	cluster, err := clusters.Get(pgClient, clusterName).Extract()
+	result := clusters.Get(pgClient, clusterName)
+	cluster, err := result.Extract()
 	if err != nil {
 		var errDefault404 gcorecloud.ErrDefault404
 		if errors.As(err, &errDefault404) {
@@ -469,6 +708,10 @@ func resourcePostgresClusterRead(ctx context.Context, data *schema.ResourceData,
 		}
 		return diag.Errorf("cannot get postgres cluster with ID: %s. Error: %s", clusterName, err)
 	}
+	var extra postgresClusterExtra
+	if err := result.ExtractInto(&extra); err != nil {
+		return diag.Errorf("cannot get postgres cluster with ID: %s. Error: %s", clusterName, err)
+	}
 	data.Set("name", cluster.ClusterName)
 	data.Set("status", cluster.Status)
 	data.Set("created_at", cluster.CreatedAt.String())
@@ -497,16 +740,42 @@ func resourcePostgresClusterRead(ctx context.Context, data *schema.ResourceData,
 	network["network_type"] = cluster.Network.NetworkType
 	network["connection_string"] = cluster.Network.ConnectionString
 	network["host"] = cluster.Network.Host
+	network["network_id"] = extra.Network.NetworkID
+	network["subnet_id"] = extra.Network.SubnetID
 	data.Set("network", []interface{}{network})
+	data.Set("primary_connection_string", cluster.Network.ConnectionString)
+
+	replicaConnectionString := ""
+	if extra.ReadReplicas != nil && extra.ReadReplicas.Count > 0 {
+		replicas := make(map[string]interface{})
+		replicas["count"] = extra.ReadReplicas.Count
+		if extra.ReadReplicas.Flavor != nil {
+			replicas["flavor"] = []interface{}{map[string]interface{}{
+				"cpu":    extra.ReadReplicas.Flavor.CPU,
+				"memory": extra.ReadReplicas.Flavor.MemoryGiB,
+			}}
+		}
+		replicas["host"] = extra.ReadReplicas.Host
+		data.Set("read_replicas", []interface{}{replicas})
+		replicaConnectionString = extra.ReadReplicas.ConnectionString
+	} else {
+		data.Set("read_replicas", nil)
+	}
+	data.Set("replica_connection_string", replicaConnectionString)
 
 	pgConfig := make(map[string]interface{})
 	pgConfig["version"] = cluster.PGServerConfiguration.Version
-	pgConfig["pg_conf"] = cluster.PGServerConfiguration.PGConf
+	parameters, _ := data.Get("pg_config.0.parameters").(map[string]interface{})
+	pgConfig["pg_conf"], pgConfig["parameters"] = splitPGConf(cluster.PGServerConfiguration.PGConf,
+		parameters, data.Get("pg_config.0.pg_conf").(string))
 	if cluster.PGServerConfiguration.Pooler != nil {
 		pgConfig["pooler_mode"] = cluster.PGServerConfiguration.Pooler.Mode
 		pgConfig["pooler_type"] = cluster.PGServerConfiguration.Pooler.Type
 	}
 	data.Set("pg_config", []interface{}{pgConfig})
+	restart := slices.Clone(extra.PGServerConfiguration.PendingRestart)
+	sort.Strings(restart)
+	data.Set("restart_required_parameters", restart)
 
 	storage := make(map[string]interface{})
 	storage["size"] = cluster.Storage.SizeGiB
@@ -522,6 +791,7 @@ func resourcePostgresClusterRead(ctx context.Context, data *schema.ResourceData,
 		users = append(users, u)
 	}
 	data.Set("user", users)
+	data.Set("backup", flattenPostgresBackupOpts(extra.Backup))
 	log.Printf("[DEBUG] Read postgres cluster %s", data.Id())
 	return nil
 }
@@ -536,7 +806,27 @@ func resourcePostgresClusterUpdate(ctx context.Context, data *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	updateOpts := clusters.UpdateOpts{}
+	if data.HasChange("pg_config.0.version") {
+		oldVersion, newVersion := data.GetChange("pg_config.0.version")
+		check, err := checkPostgresUpgrade(pgClient, clusterName, newVersion.(string))
+		if err != nil {
+			return diag.Errorf("cannot check upgrade of postgres cluster %s: %s", clusterName, err)
+		}
+		if !check.Compatible {
+			return diag.Errorf("postgres cluster %s cannot be upgraded to version %s: %s",
+				clusterName, newVersion, strings.Join(check.Issues, "; "))
+		}
+		log.Printf("[DEBUG] Upgrading postgres cluster %s from version %s to %s", clusterName, oldVersion, newVersion)
+		result := upgradePostgresCluster(pgClient, clusterName, newVersion.(string))
+		if result.Err != nil {
+			return diag.FromErr(result.Err)
+		}
+		if err = waitForTaskResult(result, postgresClusterOpTimeoutSecs, provider, data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	updateOpts := postgresClusterUpdateOpts{}
 
 	if data.HasChange("storage") {
 		storageList := data.Get("storage").([]interface{})
@@ -554,9 +844,9 @@ func resourcePostgresClusterUpdate(ctx context.Context, data *schema.ResourceDat
 		pgConfigList := data.Get("pg_config").([]interface{})
 		if len(pgConfigList) > 0 {
 			pgConfigMap := pgConfigList[0].(map[string]interface{})
+			// the version is changed by the upgrade above
 			updateOpts.PGServerConfiguration = &clusters.PGServerConfigurationUpdateOpts{
-				PGConf:  pgConfigMap["pg_conf"].(string),
-				Version: pgConfigMap["version"].(string),
+				PGConf: postgresPGConf(pgConfigMap),
 			}
 			if poolerMode, ok := pgConfigMap["pooler_mode"].(string); ok && poolerMode != "" {
 				updateOpts.PGServerConfiguration.Pooler = &clusters.PoolerOpts{
@@ -628,21 +918,24 @@ func resourcePostgresClusterUpdate(ctx context.Context, data *schema.ResourceDat
 	if data.HasChange("network") {
 		networkList := data.Get("network").([]interface{})
 		if len(networkList) > 0 {
-			networkMap := networkList[0].(map[string]interface{})
-			aclSet := networkMap["acl"].(*schema.Set)
-			acl := make([]string, 0)
-			for _, v := range aclSet.List() {
-				acl = append(acl, v.(string))
-			}
-			updateOpts.Network = &clusters.NetworkOpts{
-				ACL:         acl,
-				NetworkType: networkMap["network_type"].(string),
-			}
+			updateOpts.Network = expandPostgresNetworkOpts(networkList[0].(map[string]interface{}))
 		} else {
 			return diag.FromErr(fmt.Errorf("network must be specified"))
 		}
 	}
 
+	if data.HasChange("read_replicas") {
+		updateOpts.ReadReplicas = expandPostgresReadReplicasOpts(data.Get("read_replicas").([]interface{}))
+		if updateOpts.ReadReplicas == nil {
+			// removing the block deletes all replicas
+			updateOpts.ReadReplicas = &postgresReadReplicasOpts{Count: 0}
+		}
+	}
+
+	if data.HasChange("backup") {
+		updateOpts.Backup = expandPostgresBackupOpts(data.Get("backup").([]interface{}))
+	}
+
 	result := clusters.Update(pgClient, clusterName, updateOpts)
 	if result.Err != nil {
 		return diag.FromErr(result.Err)
@@ -701,34 +994,10 @@ func resourcePostgresClusterDelete(ctx context.Context, data *schema.ResourceDat
 	return nil
 }
 
+// suppressDiffMultiline compares pg_conf values as key=value maps, see parsePGConf
 func suppressDiffMultiline(k, old, new string, d *schema.ResourceData) bool {
-	// normalize function converts multiline string in "key=value" format to map
-	// ignores empty lines and comments, trims spaces around keys and values
-	// if a line is malformed (does not contain '='), the whole line is kept as key with empty value
-	// this way, any change in malformed lines will be detected
-	normalize := func(s string) map[string]string {
-		m := make(map[string]string)
-		sc := bufio.NewScanner(strings.NewReader(s))
-		for sc.Scan() {
-			line := strings.TrimSpace(sc.Text())
-			if line == "" || strings.HasPrefix(line, "#") {
-				continue
-			}
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) != 2 {
-				// keep whole line to detect changes in malformed lines
-				m[line] = ""
-				continue
-			}
-			key := strings.TrimSpace(parts[0])
-			val := strings.TrimSpace(parts[1])
-			m[key] = val
-		}
-		return m
-	}
-
-	oldMap := normalize(old)
-	newMap := normalize(new)
+	oldMap := parsePGConf(old)
+	newMap := parsePGConf(new)
 
 	if len(oldMap) != len(newMap) {
 		return false
@@ -778,3 +1047,161 @@ func validateDatabaseOwners(ctx context.Context, diff *schema.ResourceDiff, meta
 	}
 	return err
 }
+
+func validatePostgresNetwork(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	networkList, ok := diff.Get("network").([]interface{})
+	if !ok || len(networkList) == 0 || networkList[0] == nil {
+		return nil
+	}
+	networkMap := networkList[0].(map[string]interface{})
+	switch networkMap["network_type"].(string) {
+	case "public":
+		if networkMap["network_id"].(string) != "" || networkMap["subnet_id"].(string) != "" {
+			return fmt.Errorf("network_id and subnet_id can only be set for the private network type")
+		}
+		if diff.NewValueKnown("network.0.acl") && networkMap["acl"].(*schema.Set).Len() == 0 {
+			return fmt.Errorf("acl must be set for the public network type")
+		}
+	case "private":
+		for _, key := range []string{"network_id", "subnet_id"} {
+			if diff.NewValueKnown("network.0."+key) && networkMap[key].(string) == "" {
+				return fmt.Errorf("%s must be set for the private network type", key)
+			}
+		}
+	}
+	return nil
+}
+
+// validatePostgresConfiguration checks the version, flavor and parameters against the configuration of the region
+// and adds the changed parameters that require a restart to restart_required_parameters
+func validatePostgresConfiguration(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChanges("pg_config", "flavor") {
+		return nil
+	}
+	for _, key := range []string{"pg_config.0.version", "pg_config.0.pg_conf", "pg_config.0.parameters", "flavor.0.cpu", "flavor.0.memory"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+	oldVersion, newVersion := diff.GetChange("pg_config.0.version")
+	upgrade := diff.Id() != "" && oldVersion.(string) != newVersion.(string)
+	if upgrade {
+		oldMajor, oldErr := strconv.Atoi(oldVersion.(string))
+		newMajor, newErr := strconv.Atoi(newVersion.(string))
+		if oldErr == nil && newErr == nil && newMajor < oldMajor {
+			return fmt.Errorf("downgrade of postgres cluster from version %s to %s is not supported", oldVersion, newVersion)
+		}
+	}
+
+	client, err := postgresClientFromDiff(meta.(*Config), diff, postgresConfigurationPoint)
+	if err != nil || client == nil {
+		return err
+	}
+	configuration, err := getPostgresConfiguration(client)
+	if err != nil {
+		// the configuration only adds plan-time checks, the API validates the cluster again on apply
+		log.Printf("[WARN] Skipping postgres configuration checks, cannot get postgres configuration: %s", err)
+		return nil
+	}
+	if err := checkPostgresConfiguration(configuration, diff); err != nil {
+		return err
+	}
+
+	if diff.Id() != "" {
+		pending := diff.Get("restart_required_parameters").([]interface{})
+		restart := make([]string, 0, len(pending))
+		for _, key := range pending {
+			restart = append(restart, key.(string))
+		}
+		oldConfig, newConfig := diff.GetChange("pg_config")
+		oldConf, newConf := postgresPGConfigMap(oldConfig), postgresPGConfigMap(newConfig)
+		changed := false
+		for key, value := range newConf {
+			if oldValue, ok := oldConf[key]; ok && oldValue == value {
+				continue
+			}
+			p := configuration.parameter(key, newVersion.(string))
+			if p != nil && p.RestartRequired && !slices.Contains(restart, key) {
+				restart = append(restart, key)
+				changed = true
+			}
+		}
+		if changed {
+			sort.Strings(restart)
+			if err := diff.SetNew("restart_required_parameters", restart); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkPostgresConfiguration(configuration *postgresConfiguration, diff *schema.ResourceDiff) error {
+	version := diff.Get("pg_config.0.version").(string)
+	if !slices.Contains(configuration.Versions, version) {
+		return fmt.Errorf("postgres version %s is not available, available versions: %s",
+			version, strings.Join(configuration.Versions, ", "))
+	}
+
+	cpu, memory := diff.Get("flavor.0.cpu").(int), diff.Get("flavor.0.memory").(int)
+	flavors := make([]string, 0, len(configuration.Flavors))
+	found := len(configuration.Flavors) == 0
+	for _, flavor := range configuration.Flavors {
+		found = found || (flavor.CPU == cpu && flavor.MemoryGiB == memory)
+		flavors = append(flavors, fmt.Sprintf("%d CPU/%d GiB", flavor.CPU, flavor.MemoryGiB))
+	}
+	if !found {
+		return fmt.Errorf("flavor with %d CPU and %d GiB memory is not available, available flavors: %s",
+			cpu, memory, strings.Join(flavors, ", "))
+	}
+
+	parameters, _ := diff.Get("pg_config.0.parameters").(map[string]interface{})
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		p := configuration.parameter(name, version)
+		if p == nil {
+			return fmt.Errorf("parameter %s is not allowed for postgres version %s", name, version)
+		}
+		if err := validatePostgresParameterValue(p, parameters[name].(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postgresPGConfigMap returns the effective pg_conf of the pg_config block as a map
+func postgresPGConfigMap(pgConfig interface{}) map[string]string {
+	pgConfigList, _ := pgConfig.([]interface{})
+	if len(pgConfigList) == 0 || pgConfigList[0] == nil {
+		return map[string]string{}
+	}
+	return parsePGConf(postgresPGConf(pgConfigList[0].(map[string]interface{})))
+}
+
+// postgresClientFromDiff returns a client for plan-time checks, nil while the project or region is unknown
+func postgresClientFromDiff(config *Config, diff *schema.ResourceDiff, endpoint string) (*gcorecloud.ServiceClient, error) {
+	for _, key := range []string{"project_id", "project_name", "region_id", "region_name"} {
+		if !diff.NewValueKnown(key) {
+			return nil, nil
+		}
+	}
+	provider := config.Provider
+	projectID, err := GetProject(provider, diff.Get("project_id").(int), diff.Get("project_name").(string))
+	if err != nil {
+		return nil, err
+	}
+	regionID, err := GetRegion(provider, diff.Get("region_id").(int), diff.Get("region_name").(string))
+	if err != nil {
+		return nil, err
+	}
+	return gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    endpoint,
+		Region:  regionID,
+		Project: projectID,
+		Version: "v1",
+	})
+}
@@ -0,0 +1,254 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/dbaas/postgres/v1/clusters"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePostgresUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePostgresUserCreate,
+		ReadContext:   resourcePostgresUserRead,
+		UpdateContext: resourcePostgresUserUpdate,
+		DeleteContext: resourcePostgresUserDelete,
+		CustomizeDiff: resourcePostgresUserCustomizeDiff,
+		Description: "Represents the password of a PostgreSQL cluster user in Gcore Cloud. " +
+			"The user itself is declared in the `user` block of `gcore_postgres_cluster`. " +
+			"Deleting the resource keeps the current password valid.",
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"project_id", "project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+				Description:      "Project ID, only one of project_id or project_name should be set",
+			},
+			"region_id": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"region_id", "region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+				Description:      "Region ID, only one of region_id or region_name should be set",
+			},
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"project_id", "project_name"},
+				Description:  "Project name, only one of project_id or project_name should be set",
+			},
+			"region_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"region_id", "region_name"},
+				Description:  "Region name, only one of region_id or region_name should be set",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the PostgreSQL cluster.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "User name, must be declared in the `user` block of the cluster.",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Database of `connection_uri`.",
+			},
+			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ValidateFunc:  validation.StringLenBetween(8, 128),
+				ConflictsWith: []string{"password_rotation_triggers"},
+				Description:   "User password. A password is generated into `generated_password` if not set.",
+			},
+			"password_rotation_triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values, a new password is generated when any of them changes.",
+			},
+			"generated_password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Generated user password, empty if `password` is set.",
+			},
+			"connection_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Connection URI of the primary instance with the user credentials.",
+			},
+			"is_secret_revealed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the password of the user is revealed.",
+			},
+		},
+	}
+}
+
+// plan password generation when triggers change or the password is removed
+func resourcePostgresUserCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	oldPassword, newPassword := diff.GetChange("password")
+	if diff.HasChange("password_rotation_triggers") || (oldPassword.(string) != "" && newPassword.(string) == "") {
+		if err := diff.SetNewComputed("generated_password"); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("connection_uri")
+	}
+	if diff.HasChange("password") || diff.HasChange("database") {
+		return diff.SetNewComputed("connection_uri")
+	}
+	return nil
+}
+
+func resourcePostgresUserCreate(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start postgres user create")
+	config := m.(*Config)
+	provider := config.Provider
+	clusterName := data.Get("cluster_name").(string)
+	userName := data.Get("name").(string)
+
+	pgClient, err := CreateClient(provider, data, postgresClustersPoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cluster, err := clusters.Get(pgClient, clusterName).Extract()
+	if err != nil {
+		return diag.Errorf("cannot get postgres cluster with ID: %s. Error: %s", clusterName, err)
+	}
+	if findPostgresUser(cluster, userName) == nil {
+		return diag.Errorf("user %q not found in postgres cluster %s. Add a user with name %q in the user block of the cluster",
+			userName, clusterName, userName)
+	}
+
+	if err := applyPostgresUserPassword(pgClient, data); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(fmt.Sprintf("%s:%s", clusterName, userName))
+
+	log.Println("[DEBUG] Finished postgres user create")
+	return resourcePostgresUserRead(ctx, data, m)
+}
+
+func resourcePostgresUserRead(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start postgres user read")
+	config := m.(*Config)
+	provider := config.Provider
+	clusterName := data.Get("cluster_name").(string)
+	userName := data.Get("name").(string)
+
+	pgClient, err := CreateClient(provider, data, postgresClustersPoint, "v1")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cluster, err := clusters.Get(pgClient, clusterName).Extract()
+	if err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if errors.As(err, &errDefault404) {
+			log.Printf("[WARN] postgres cluster %s not found, removing user %s from state", clusterName, userName)
+			data.SetId("")
+			return nil
+		}
+		return diag.Errorf("cannot get postgres cluster with ID: %s. Error: %s", clusterName, err)
+	}
+	user := findPostgresUser(cluster, userName)
+	if user == nil {
+		log.Printf("[WARN] postgres user %s not found in cluster %s, removing from state", userName, clusterName)
+		data.SetId("")
+		return nil
+	}
+
+	// passwords are only returned on reveal, keep the known one
+	password := data.Get("password").(string)
+	if password == "" {
+		password = data.Get("generated_password").(string)
+	}
+	data.Set("is_secret_revealed", user.IsSecretRevealed)
+	data.Set("connection_uri", postgresConnectionURI(cluster.Network.ConnectionString, cluster.Network.Host,
+		userName, password, data.Get("database").(string)))
+
+	log.Printf("[DEBUG] Read postgres user %s", data.Id())
+	return nil
+}
+
+func resourcePostgresUserUpdate(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start postgres user update")
+	config := m.(*Config)
+	provider := config.Provider
+
+	if data.HasChanges("password", "password_rotation_triggers") {
+		pgClient, err := CreateClient(provider, data, postgresClustersPoint, "v1")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := applyPostgresUserPassword(pgClient, data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePostgresUserRead(ctx, data, m)
+}
+
+func resourcePostgresUserDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Postgres user %s removed from state, password stays valid", data.Id())
+	data.SetId("")
+	return nil
+}
+
+// applyPostgresUserPassword sets the configured password or generates a new one
+func applyPostgresUserPassword(client *gcorecloud.ServiceClient, data *schema.ResourceData) error {
+	clusterName := data.Get("cluster_name").(string)
+	userName := data.Get("name").(string)
+
+	if password := data.Get("password").(string); password != "" {
+		if err := setPostgresUserPassword(client, clusterName, userName, password); err != nil {
+			return fmt.Errorf("cannot set password of postgres user %s: %w", userName, err)
+		}
+		data.Set("generated_password", "")
+		return nil
+	}
+
+	creds, err := generatePostgresUserPassword(client, clusterName, userName)
+	if err != nil {
+		return fmt.Errorf("cannot generate password of postgres user %s: %w", userName, err)
+	}
+	if creds.Password == "" {
+		return fmt.Errorf("cannot generate password of postgres user %s: no password returned", userName)
+	}
+	data.Set("generated_password", creds.Password)
+	return nil
+}
+
+func findPostgresUser(cluster *clusters.PostgresSQLCluster, userName string) *clusters.PgUserOverview {
+	for i := range cluster.Users {
+		if cluster.Users[i].Name == userName {
+			return &cluster.Users[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/snapshot/v1/snapshots"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSnapshotCopy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSnapshotCopyCreate,
+		ReadContext:   resourceSnapshotCopyRead,
+		DeleteContext: resourceSnapshotCopyDelete,
+		Description: "Represent a copy of a volume snapshot in another region of the project. " +
+			"Use `snapshot_id` of `gcore_volume` with the copy ID and `destination_region_id` to restore the volume there",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+				Description:      "Region of the source snapshot",
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				Description: "Region of the source snapshot",
+			},
+			"source_snapshot_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the snapshot to copy",
+			},
+			"destination_region_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Region to copy the snapshot to",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Name of the copy, the name of the source snapshot is used if not set",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSnapshotCopyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start snapshot copy creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, snapshotsPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sourceID := d.Get("source_snapshot_id").(string)
+	opts := snapshotCopyOpts{
+		RegionID:    d.Get("destination_region_id").(int),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+	results, err := copySnapshot(client, sourceID, opts).Extract()
+	if err != nil {
+		return diag.Errorf("cannot copy snapshot %s to region %d. Error: %s", sourceID, opts.RegionID, err)
+	}
+	if len(results.Tasks) == 0 {
+		return diag.FromErr(errors.New("no task IDs returned"))
+	}
+
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	copyID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, snapshotCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(client, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		snapshotID, err := snapshots.ExtractSnapshotIDFromTask(taskInfo)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve snapshot ID from task info: %w", err)
+		}
+		return snapshotID, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(copyID.(string))
+	log.Printf("[DEBUG] Finish snapshot copy creating (%s)", d.Id())
+	return resourceSnapshotCopyRead(ctx, d, m)
+}
+
+func resourceSnapshotCopyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start snapshot copy reading")
+	config := m.(*Config)
+	provider := config.Provider
+	copyID := d.Id()
+
+	client, err := createSnapshotClientInRegion(provider, d, d.Get("destination_region_id").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshot, err := snapshots.Get(client, copyID).Extract()
+	if err != nil {
+		var errDefault404 gcorecloud.ErrDefault404
+		if errors.As(err, &errDefault404) {
+			// removing from state because it doesn't exist anymore
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("cannot get snapshot copy with ID: %s. Error: %s", copyID, err)
+	}
+
+	d.Set("name", snapshot.Name)
+	d.Set("description", snapshot.Description)
+	d.Set("status", snapshot.Status)
+	d.Set("size", snapshot.Size)
+
+	log.Println("[DEBUG] Finish snapshot copy reading")
+	return nil
+}
+
+func resourceSnapshotCopyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start snapshot copy deleting")
+	config := m.(*Config)
+	provider := config.Provider
+	copyID := d.Id()
+
+	client, err := createSnapshotClientInRegion(provider, d, d.Get("destination_region_id").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results, err := snapshots.Delete(client, copyID).Extract()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, snapshotDeleting, func(task tasks.TaskID) (interface{}, error) {
+		_, err := snapshots.Get(client, copyID).Extract()
+		if err == nil {
+			return nil, fmt.Errorf("cannot delete snapshot copy with ID: %s", copyID)
+		}
+		switch err.(type) {
+		case gcorecloud.ErrDefault404:
+			return nil, nil
+		default:
+			return nil, err
+		}
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Printf("[DEBUG] Finish of snapshot copy deleting")
+	return nil
+}
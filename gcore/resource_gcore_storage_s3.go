@@ -59,7 +59,7 @@ func resourceStorageS3() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "A location of new storage resource.",
+				Description: "A location of new storage resource, checked at plan time against s3 locations of gcore_storage_locations.",
 			},
 			StorageS3SchemaGenerateAccessKey: {
 				Type:        schema.TypeString,
@@ -95,6 +95,7 @@ func resourceStorageS3() *schema.Resource {
 		CreateContext: resourceStorageS3Create,
 		ReadContext:   resourceStorageS3Read,
 		DeleteContext: resourceStorageS3Delete,
+		CustomizeDiff: validateStorageLocationDiff("s3"),
 		Description:   "Represent s3 storage resource. https://storage.gcore.com/storage/list",
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -182,9 +183,9 @@ func resourceStorageS3Read(ctx context.Context, d *schema.ResourceData, m interf
 	}
 	_ = d.Set(StorageSchemaId, st.ID)
 	_ = d.Set(StorageSchemaLocation, st.Location)
-	_ = d.Set(StorageSchemaGenerateEndpoint, fmt.Sprintf("%s.cloud.gcore.lu/%s", st.Location, st.Name))
-	_ = d.Set(StorageSchemaGenerateHTTPEndpoint, fmt.Sprintf("https://%s.cloud.gcore.lu/{bucket_name}", st.Location))
-	_ = d.Set(StorageSchemaGenerateS3Endpoint, fmt.Sprintf("https://%s.cloud.gcore.lu", st.Location))
+	_ = d.Set(StorageSchemaGenerateEndpoint, fmt.Sprintf("%s/%s", storageS3Host(st.Location), st.Name))
+	_ = d.Set(StorageSchemaGenerateHTTPEndpoint, fmt.Sprintf("https://%s/{bucket_name}", storageS3Host(st.Location)))
+	_ = d.Set(StorageSchemaGenerateS3Endpoint, "https://"+storageS3Host(st.Location))
 
 	return nil
 }
@@ -2,6 +2,7 @@ package gcore
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
 	"log"
 	"regexp"
@@ -12,13 +13,28 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	StorageS3BucketSchemaName      = "name"
-	StorageS3BucketSchemaStorageID = "storage_id"
+	StorageS3BucketSchemaName          = "name"
+	StorageS3BucketSchemaStorageID     = "storage_id"
+	StorageS3BucketSchemaAccessKey     = "access_key"
+	StorageS3BucketSchemaSecretKey     = "secret_key"
+	StorageS3BucketSchemaCORSRule      = "cors_rule"
+	StorageS3BucketSchemaLifecycleRule = "lifecycle_rule"
+	StorageS3BucketSchemaPolicy        = "policy"
+
+	storageS3BucketPolicyPrivate    = "private"
+	storageS3BucketPolicyPublicRead = "public-read"
+	storageS3BucketPolicyCustom     = "custom"
 )
 
+// storageS3BucketPublicReadPolicy allows anonymous reads of all bucket objects
+const storageS3BucketPublicReadPolicy = `{"Version":"2012-10-17","Statement":[{"Sid":"PublicRead","Effect":"Allow",` +
+	`"Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`
+
 func resourceStorageS3Bucket() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -43,11 +59,133 @@ func resourceStorageS3Bucket() *schema.Resource {
 				},
 				Description: "A name of new storage bucket resource.",
 			},
+			StorageS3BucketSchemaAccessKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{StorageS3BucketSchemaSecretKey},
+				Description:  "S3 access key of the storage, e.g. generated_access_key of gcore_storage_s3. Required to manage bucket settings.",
+			},
+			StorageS3BucketSchemaSecretKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{StorageS3BucketSchemaAccessKey},
+				Description:  "S3 secret key of the storage, e.g. generated_secret_key of gcore_storage_s3. Required to manage bucket settings.",
+			},
+			StorageS3BucketSchemaCORSRule: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				RequiredWith: []string{StorageS3BucketSchemaAccessKey},
+				Description:  "CORS rules of the bucket.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allowed_origins": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Origins allowed to make cross-origin requests, `*` allows all origins.",
+						},
+						"allowed_methods": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"GET", "PUT", "POST", "DELETE", "HEAD"}, false),
+							},
+							Description: "Allowed methods, one of GET, PUT, POST, DELETE or HEAD.",
+						},
+						"allowed_headers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Headers allowed in preflight requests.",
+						},
+						"expose_headers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Response headers accessible to clients.",
+						},
+						"max_age_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Time in seconds browsers may cache the preflight response.",
+						},
+					},
+				},
+			},
+			StorageS3BucketSchemaLifecycleRule: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				RequiredWith: []string{StorageS3BucketSchemaAccessKey},
+				Description:  "Lifecycle rules of the bucket, expiring objects after a number of days.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Rule id.",
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Key prefix of the objects the rule applies to, all objects if empty.",
+						},
+						"expiration_days": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Number of days after creation when objects are deleted.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the rule is applied.",
+						},
+					},
+				},
+			},
+			StorageS3BucketSchemaPolicy: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				RequiredWith: []string{StorageS3BucketSchemaAccessKey},
+				Description:  "Access policy of the bucket.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								storageS3BucketPolicyPrivate, storageS3BucketPolicyPublicRead, storageS3BucketPolicyCustom,
+							}, false),
+							Description: "Policy type: `private` (no bucket policy), `public-read` (anonymous reads of all objects) or `custom`.",
+						},
+						"document": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+							Description:      "JSON policy document, required for the `custom` type.",
+						},
+					},
+				},
+			},
 		},
 		CreateContext: resourceStorageS3BucketCreate,
 		ReadContext:   resourceStorageS3BucketRead,
+		UpdateContext: resourceStorageS3BucketUpdate,
 		DeleteContext: resourceStorageS3BucketDelete,
-		Description:   "Represent s3 storage bucket resource. https://storage.gcore.com/storage/list",
+		CustomizeDiff: resourceStorageS3BucketCustomizeDiff,
+		Description: "Represent s3 storage bucket resource. https://storage.gcore.com/storage/list\n\n" +
+			"CORS rules, lifecycle rules and the access policy are managed via the S3 API with the storage keys " +
+			"and are only read back when `access_key` and `secret_key` are set.",
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -78,6 +216,10 @@ func resourceStorageS3BucketCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	d.SetId(fmt.Sprintf("%d:%s", id, name))
 
+	if err := applyStorageS3BucketSettings(ctx, d, config, true); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceStorageS3BucketRead(ctx, d, m)
 }
 
@@ -106,12 +248,257 @@ func resourceStorageS3BucketRead(ctx context.Context, d *schema.ResourceData, m
 			d.SetId(fmt.Sprintf("%d:%s", storageId, bucketName))
 			_ = d.Set(StorageS3BucketSchemaStorageID, storageId)
 			_ = d.Set(StorageS3BucketSchemaName, bucketName)
+			if err := readStorageS3BucketSettings(ctx, d, config); err != nil {
+				return diag.FromErr(err)
+			}
 			return nil
 		}
 	}
 	return diag.FromErr(fmt.Errorf("storage buckets list has not this bucket"))
 }
 
+func resourceStorageS3BucketUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	storageId, bucketName := storageBucketResourceID(d)
+	log.Printf("[DEBUG] Start S3 Storage Bucket Resource updating (id=%d, name=%s)\n", storageId, bucketName)
+	defer log.Println("[DEBUG] Finish S3 Storage Bucket Resource updating")
+
+	if err := applyStorageS3BucketSettings(ctx, d, m.(*Config), false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStorageS3BucketRead(ctx, d, m)
+}
+
+func resourceStorageS3BucketCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	for _, p := range diff.Get(StorageS3BucketSchemaPolicy).([]interface{}) {
+		if p == nil {
+			continue
+		}
+		policy := p.(map[string]interface{})
+		document := policy["document"].(string)
+		if !diff.NewValueKnown(StorageS3BucketSchemaPolicy + ".0.document") {
+			continue
+		}
+		switch t := policy["type"].(string); {
+		case t == storageS3BucketPolicyCustom && document == "":
+			return fmt.Errorf("policy: document is required for the %s type", t)
+		case t != storageS3BucketPolicyCustom && document != "":
+			return fmt.Errorf("policy: document can only be set for the %s type", storageS3BucketPolicyCustom)
+		}
+	}
+	return nil
+}
+
+// storageS3BucketClient returns the S3 API client for the bucket, nil if the storage keys are not set
+// (or not in the schema, as in the bucket data source)
+func storageS3BucketClient(ctx context.Context, d *schema.ResourceData, config *Config) (*s3Client, error) {
+	accessKey, _ := d.Get(StorageS3BucketSchemaAccessKey).(string)
+	secretKey, _ := d.Get(StorageS3BucketSchemaSecretKey).(string)
+	if accessKey == "" || secretKey == "" {
+		return nil, nil
+	}
+	storageId, _ := storageBucketResourceID(d)
+	return newStorageS3Client(ctx, config, storageId, accessKey, secretKey)
+}
+
+// applyStorageS3BucketSettings updates CORS rules, lifecycle rules and policy, all configured settings on create
+// and changed ones on update. Emptied settings are deleted.
+func applyStorageS3BucketSettings(ctx context.Context, d *schema.ResourceData, config *Config, create bool) error {
+	changed := func(key string) bool {
+		if create {
+			return len(d.Get(key).([]interface{})) > 0
+		}
+		return d.HasChange(key)
+	}
+	if !changed(StorageS3BucketSchemaCORSRule) && !changed(StorageS3BucketSchemaLifecycleRule) && !changed(StorageS3BucketSchemaPolicy) {
+		return nil
+	}
+	s3, err := storageS3BucketClient(ctx, d, config)
+	if err != nil {
+		return err
+	}
+	if s3 == nil {
+		return fmt.Errorf("%s and %s are required to manage bucket settings", StorageS3BucketSchemaAccessKey, StorageS3BucketSchemaSecretKey)
+	}
+	_, bucketName := storageBucketResourceID(d)
+
+	if changed(StorageS3BucketSchemaCORSRule) {
+		cors := expandStorageS3BucketCORS(d.Get(StorageS3BucketSchemaCORSRule).([]interface{}))
+		if err := putStorageS3BucketSetting(ctx, s3, bucketName, "cors", cors, len(cors.Rules) == 0); err != nil {
+			return fmt.Errorf("update bucket CORS rules: %w", err)
+		}
+	}
+	if changed(StorageS3BucketSchemaLifecycleRule) {
+		lifecycle := expandStorageS3BucketLifecycle(d.Get(StorageS3BucketSchemaLifecycleRule).([]interface{}))
+		if err := putStorageS3BucketSetting(ctx, s3, bucketName, "lifecycle", lifecycle, len(lifecycle.Rules) == 0); err != nil {
+			return fmt.Errorf("update bucket lifecycle rules: %w", err)
+		}
+	}
+	if changed(StorageS3BucketSchemaPolicy) {
+		document := storageS3BucketPolicyDocument(bucketName, d.Get(StorageS3BucketSchemaPolicy).([]interface{}))
+		if document == "" {
+			err = s3.deleteBucketSubresource(ctx, bucketName, "policy")
+		} else {
+			err = s3.putBucketSubresource(ctx, bucketName, "policy", []byte(document))
+		}
+		if err != nil {
+			return fmt.Errorf("update bucket policy: %w", err)
+		}
+	}
+	return nil
+}
+
+func putStorageS3BucketSetting(ctx context.Context, s3 *s3Client, bucketName, subresource string, v interface{}, empty bool) error {
+	if empty {
+		return s3.deleteBucketSubresource(ctx, bucketName, subresource)
+	}
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s3.putBucketSubresource(ctx, bucketName, subresource, body)
+}
+
+// readStorageS3BucketSettings reads CORS rules, lifecycle rules and policy into state for drift detection
+func readStorageS3BucketSettings(ctx context.Context, d *schema.ResourceData, config *Config) error {
+	s3, err := storageS3BucketClient(ctx, d, config)
+	if err != nil || s3 == nil {
+		return err
+	}
+	_, bucketName := storageBucketResourceID(d)
+
+	var cors s3CORSConfiguration
+	if data, found, err := s3.getBucketSubresource(ctx, bucketName, "cors"); err != nil {
+		return fmt.Errorf("get bucket CORS rules: %w", err)
+	} else if found {
+		if err := xml.Unmarshal(data, &cors); err != nil {
+			return fmt.Errorf("get bucket CORS rules: %w", err)
+		}
+	}
+	if err := d.Set(StorageS3BucketSchemaCORSRule, flattenStorageS3BucketCORS(cors)); err != nil {
+		return err
+	}
+
+	var lifecycle s3LifecycleConfiguration
+	if data, found, err := s3.getBucketSubresource(ctx, bucketName, "lifecycle"); err != nil {
+		return fmt.Errorf("get bucket lifecycle rules: %w", err)
+	} else if found {
+		if err := xml.Unmarshal(data, &lifecycle); err != nil {
+			return fmt.Errorf("get bucket lifecycle rules: %w", err)
+		}
+	}
+	if err := d.Set(StorageS3BucketSchemaLifecycleRule, flattenStorageS3BucketLifecycle(lifecycle)); err != nil {
+		return err
+	}
+
+	document, _, err := s3.getBucketSubresource(ctx, bucketName, "policy")
+	if err != nil {
+		return fmt.Errorf("get bucket policy: %w", err)
+	}
+	configured := len(d.Get(StorageS3BucketSchemaPolicy).([]interface{})) > 0
+	return d.Set(StorageS3BucketSchemaPolicy, flattenStorageS3BucketPolicy(bucketName, string(document), configured))
+}
+
+func expandStorageS3BucketCORS(rules []interface{}) s3CORSConfiguration {
+	var cors s3CORSConfiguration
+	for _, r := range rules {
+		rule := r.(map[string]interface{})
+		cors.Rules = append(cors.Rules, s3CORSRule{
+			AllowedOrigins: expandStringList(rule["allowed_origins"].([]interface{})),
+			AllowedMethods: expandStringList(rule["allowed_methods"].([]interface{})),
+			AllowedHeaders: expandStringList(rule["allowed_headers"].([]interface{})),
+			ExposeHeaders:  expandStringList(rule["expose_headers"].([]interface{})),
+			MaxAgeSeconds:  rule["max_age_seconds"].(int),
+		})
+	}
+	return cors
+}
+
+func flattenStorageS3BucketCORS(cors s3CORSConfiguration) []interface{} {
+	rules := make([]interface{}, 0, len(cors.Rules))
+	for _, rule := range cors.Rules {
+		rules = append(rules, map[string]interface{}{
+			"allowed_origins": rule.AllowedOrigins,
+			"allowed_methods": rule.AllowedMethods,
+			"allowed_headers": rule.AllowedHeaders,
+			"expose_headers":  rule.ExposeHeaders,
+			"max_age_seconds": rule.MaxAgeSeconds,
+		})
+	}
+	return rules
+}
+
+func expandStorageS3BucketLifecycle(rules []interface{}) s3LifecycleConfiguration {
+	var lifecycle s3LifecycleConfiguration
+	for i, r := range rules {
+		rule := r.(map[string]interface{})
+		id := rule["id"].(string)
+		if id == "" {
+			id = fmt.Sprintf("rule-%d", i+1)
+		}
+		status := "Disabled"
+		if rule["enabled"].(bool) {
+			status = "Enabled"
+		}
+		lr := s3LifecycleRule{ID: id, Filter: &s3LifecycleFilter{Prefix: rule["prefix"].(string)}, Status: status}
+		lr.Expiration.Days = rule["expiration_days"].(int)
+		lifecycle.Rules = append(lifecycle.Rules, lr)
+	}
+	return lifecycle
+}
+
+func flattenStorageS3BucketLifecycle(lifecycle s3LifecycleConfiguration) []interface{} {
+	rules := make([]interface{}, 0, len(lifecycle.Rules))
+	for _, rule := range lifecycle.Rules {
+		prefix := rule.Prefix
+		if rule.Filter != nil {
+			prefix = rule.Filter.Prefix
+		}
+		rules = append(rules, map[string]interface{}{
+			"id":              rule.ID,
+			"prefix":          prefix,
+			"expiration_days": rule.Expiration.Days,
+			"enabled":         rule.Status == "Enabled",
+		})
+	}
+	return rules
+}
+
+// storageS3BucketPolicyDocument returns the policy to put, empty for no bucket policy
+func storageS3BucketPolicyDocument(bucketName string, policies []interface{}) string {
+	if len(policies) == 0 || policies[0] == nil {
+		return ""
+	}
+	policy := policies[0].(map[string]interface{})
+	switch policy["type"].(string) {
+	case storageS3BucketPolicyPublicRead:
+		return fmt.Sprintf(storageS3BucketPublicReadPolicy, bucketName)
+	case storageS3BucketPolicyCustom:
+		return policy["document"].(string)
+	}
+	return ""
+}
+
+// flattenStorageS3BucketPolicy maps the bucket policy document to the policy block, no policy is
+// private if the block is configured
+func flattenStorageS3BucketPolicy(bucketName, document string, configured bool) []interface{} {
+	if document == "" {
+		if !configured {
+			return nil
+		}
+		return []interface{}{map[string]interface{}{"type": storageS3BucketPolicyPrivate, "document": ""}}
+	}
+	normalized, err := structure.NormalizeJsonString(document)
+	if err != nil {
+		normalized = document
+	}
+	publicRead, _ := structure.NormalizeJsonString(fmt.Sprintf(storageS3BucketPublicReadPolicy, bucketName))
+	if normalized == publicRead {
+		return []interface{}{map[string]interface{}{"type": storageS3BucketPolicyPublicRead, "document": ""}}
+	}
+	return []interface{}{map[string]interface{}{"type": storageS3BucketPolicyCustom, "document": normalized}}
+}
+
 func resourceStorageS3BucketDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	storageId, bucketName := storageBucketResourceID(d)
 	log.Printf("[DEBUG] Start S3 Storage Bucket Resource deleting (id=%d,name=%s)\n", storageId, bucketName)
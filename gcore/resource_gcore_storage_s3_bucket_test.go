@@ -34,6 +34,36 @@ resource "gcore_storage_s3_bucket" "terraform_test_%d_s3_bucket" {
 }
 		`, random, random, random, random, storageResourceName)
 	}
+	templateBucketSettings := func() string {
+		return fmt.Sprintf(`
+resource "gcore_storage_s3" "terraform_test_%d_s3" {
+  name = "terraform_test_%d"
+  location = "s-region-1"
+}
+
+resource "gcore_storage_s3_bucket" "terraform_test_%d_s3_bucket" {
+  name = "terraform_test_%d"
+  storage_id = %s.id
+  access_key = %s.generated_access_key
+  secret_key = %s.generated_secret_key
+
+  cors_rule {
+    allowed_origins = ["https://example.com"]
+    allowed_methods = ["GET", "HEAD"]
+    max_age_seconds = 600
+  }
+
+  lifecycle_rule {
+    prefix          = "tmp/"
+    expiration_days = 7
+  }
+
+  policy {
+    type = "public-read"
+  }
+}
+		`, random, random, random, random, storageResourceName, storageResourceName, storageResourceName)
+	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -74,6 +104,16 @@ resource "gcore_storage_s3_bucket" "terraform_test_%d_s3_bucket" {
 					resource.TestCheckResourceAttr(bucketResourceName, StorageS3BucketSchemaName, name),
 				),
 			},
+			{
+				Config: templateBucketSettings(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(bucketResourceName),
+					resource.TestCheckResourceAttr(bucketResourceName, "cors_rule.0.allowed_origins.0", "https://example.com"),
+					resource.TestCheckResourceAttr(bucketResourceName, "lifecycle_rule.0.expiration_days", "7"),
+					resource.TestCheckResourceAttr(bucketResourceName, "lifecycle_rule.0.prefix", "tmp/"),
+					resource.TestCheckResourceAttr(bucketResourceName, "policy.0.type", "public-read"),
+				),
+			},
 		},
 	})
 }
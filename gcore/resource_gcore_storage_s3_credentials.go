@@ -0,0 +1,206 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/client/storage"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	StorageS3CredentialsSchemaStorageID      = "storage_id"
+	StorageS3CredentialsSchemaRotationPeriod = "rotation_period"
+	StorageS3CredentialsSchemaTriggers       = "triggers"
+	StorageS3CredentialsSchemaAccessKey      = "access_key"
+	StorageS3CredentialsSchemaSecretKey      = "secret_key"
+	StorageS3CredentialsSchemaRotatedAt      = "rotated_at"
+	StorageS3CredentialsSchemaNextRotationAt = "next_rotation_at"
+)
+
+func resourceStorageS3Credentials() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			StorageS3CredentialsSchemaStorageID: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "An id of existing s3 storage resource.",
+			},
+			StorageS3CredentialsSchemaRotationPeriod: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+					period, err := time.ParseDuration(i.(string))
+					if err != nil || period <= 0 {
+						return nil, []error{fmt.Errorf("%s must be a positive duration, e.g. 720h, got %q", k, i)}
+					}
+					return nil, nil
+				},
+				Description: "Keys are rotated on the first apply after this period since the last rotation, e.g. `720h`.",
+			},
+			StorageS3CredentialsSchemaTriggers: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values, keys are rotated when any of them changes.",
+			},
+			StorageS3CredentialsSchemaAccessKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "S3 access key.",
+			},
+			StorageS3CredentialsSchemaSecretKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "S3 secret key.",
+			},
+			StorageS3CredentialsSchemaRotatedAt: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last rotation, in RFC 3339 format.",
+			},
+			StorageS3CredentialsSchemaNextRotationAt: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time after which the keys are rotated on apply, in RFC 3339 format. Empty without `rotation_period`.",
+			},
+		},
+		CreateContext: resourceStorageS3CredentialsCreate,
+		ReadContext:   resourceStorageS3CredentialsRead,
+		UpdateContext: resourceStorageS3CredentialsUpdate,
+		DeleteContext: resourceStorageS3CredentialsDelete,
+		CustomizeDiff: resourceStorageS3CredentialsCustomizeDiff,
+		Description: "Represent s3 storage access keys. New keys are generated on create and on rotation, " +
+			"which invalidates the previous keys, including `generated_access_key` and `generated_secret_key` of `gcore_storage_s3`. " +
+			"Buckets and objects of the storage are not affected. Deleting the resource keeps the current keys valid.",
+	}
+}
+
+// plan rotation when triggers change or the rotation period has passed
+func resourceStorageS3CredentialsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	rotatedAt, _ := diff.GetChange(StorageS3CredentialsSchemaRotatedAt)
+	period := diff.Get(StorageS3CredentialsSchemaRotationPeriod).(string)
+	if !diff.HasChange(StorageS3CredentialsSchemaTriggers) && !storageS3CredentialsRotationDue(rotatedAt.(string), period, time.Now()) {
+		if !diff.HasChange(StorageS3CredentialsSchemaRotationPeriod) {
+			return nil
+		}
+		return diff.SetNew(StorageS3CredentialsSchemaNextRotationAt, storageS3CredentialsNextRotation(rotatedAt.(string), period))
+	}
+	for _, key := range []string{
+		StorageS3CredentialsSchemaAccessKey, StorageS3CredentialsSchemaSecretKey,
+		StorageS3CredentialsSchemaRotatedAt, StorageS3CredentialsSchemaNextRotationAt,
+	} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceStorageS3CredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Get(StorageS3CredentialsSchemaStorageID).(int)
+	log.Printf("[DEBUG] Start S3 Storage Credentials Resource creating (id=%d)\n", id)
+	defer log.Println("[DEBUG] Finish S3 Storage Credentials Resource creating")
+
+	if err := resourceStorageS3CredentialsRotate(ctx, d, m.(*Config)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprint(id))
+
+	return resourceStorageS3CredentialsRead(ctx, d, m)
+}
+
+func resourceStorageS3CredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resourceId := d.Id()
+	log.Printf("[DEBUG] Start S3 Storage Credentials Resource reading (id=%s)\n", resourceId)
+	defer log.Println("[DEBUG] Finish S3 Storage Credentials Resource reading")
+
+	config := m.(*Config)
+	client := config.StorageClient
+
+	// keys are only returned on generation, so just check the storage still exists
+	result, err := client.StoragesList(func(opt *storage.StorageListHTTPV2Params) {
+		opt.Context = ctx
+		opt.ID = &resourceId
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("storages list: %w", err))
+	}
+	if len(result) == 0 || result[0].ProvisioningStatus == "deleted" {
+		log.Printf("[WARN] S3 Storage %s not found, removing credentials from state\n", resourceId)
+		d.SetId("")
+		return nil
+	}
+	return nil
+}
+
+func resourceStorageS3CredentialsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start S3 Storage Credentials Resource updating (id=%s)\n", d.Id())
+	defer log.Println("[DEBUG] Finish S3 Storage Credentials Resource updating")
+
+	// rotate only as planned, a rotation period expiring after plan is handled by the next plan
+	if plan := d.GetRawPlan(); !plan.IsNull() && !plan.GetAttr(StorageS3CredentialsSchemaRotatedAt).IsKnown() {
+		if err := resourceStorageS3CredentialsRotate(ctx, d, m.(*Config)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStorageS3CredentialsRead(ctx, d, m)
+}
+
+func resourceStorageS3CredentialsDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] S3 Storage Credentials Resource removed from state, keys stay valid (id=%s)\n", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// resourceStorageS3CredentialsRotate generates new keys of the storage
+func resourceStorageS3CredentialsRotate(ctx context.Context, d *schema.ResourceData, config *Config) error {
+	id := d.Get(StorageS3CredentialsSchemaStorageID).(int)
+	creds, err := config.StorageClient.UpdateStorageCredentials(func(params *storage.StorageUpdateCredentialsHTTPParams) {
+		params.ID = int64(id)
+		params.Context = ctx
+		params.Body.GenerateS3Keys = true
+	})
+	if err != nil {
+		return fmt.Errorf("generate storage keys: %w", err)
+	}
+	if creds.S3 == nil || creds.S3.AccessKey == "" || creds.S3.SecretKey == "" {
+		return fmt.Errorf("generate storage keys: no keys returned for storage %d", id)
+	}
+
+	rotatedAt := time.Now().UTC().Format(time.RFC3339)
+	_ = d.Set(StorageS3CredentialsSchemaAccessKey, creds.S3.AccessKey)
+	_ = d.Set(StorageS3CredentialsSchemaSecretKey, creds.S3.SecretKey)
+	_ = d.Set(StorageS3CredentialsSchemaRotatedAt, rotatedAt)
+	_ = d.Set(StorageS3CredentialsSchemaNextRotationAt,
+		storageS3CredentialsNextRotation(rotatedAt, d.Get(StorageS3CredentialsSchemaRotationPeriod).(string)))
+	return nil
+}
+
+// storageS3CredentialsNextRotation returns the time the keys are due for rotation, empty without a rotation period
+func storageS3CredentialsNextRotation(rotatedAt, period string) string {
+	last, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return ""
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return ""
+	}
+	return last.Add(d).UTC().Format(time.RFC3339)
+}
+
+func storageS3CredentialsRotationDue(rotatedAt, period string, now time.Time) bool {
+	next, err := time.Parse(time.RFC3339, storageS3CredentialsNextRotation(rotatedAt, period))
+	return err == nil && !now.Before(next)
+}
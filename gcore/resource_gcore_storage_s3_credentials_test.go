@@ -0,0 +1,91 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccStorageS3Credentials(t *testing.T) {
+
+	random := time.Now().Nanosecond()
+	storageResourceName := fmt.Sprintf("gcore_storage_s3.terraform_test_%d_s3", random)
+	credentialsResourceName := fmt.Sprintf("gcore_storage_s3_credentials.terraform_test_%d_s3_credentials", random)
+
+	template := func(trigger string) string {
+		return fmt.Sprintf(`
+resource "gcore_storage_s3" "terraform_test_%[1]d_s3" {
+  name = "terraform_test_%[1]d"
+  location = "s-region-1"
+}
+
+resource "gcore_storage_s3_credentials" "terraform_test_%[1]d_s3_credentials" {
+  storage_id = %[2]s.id
+  rotation_period = "720h"
+  triggers = {
+    rotation = %[3]q
+  }
+}
+		`, random, storageResourceName, trigger)
+	}
+
+	var accessKey string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckVars(t, GCORE_USERNAME_VAR, GCORE_PASSWORD_VAR, GCORE_STORAGE_URL_VAR)
+		},
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: template("1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(credentialsResourceName),
+					resource.TestCheckResourceAttrSet(credentialsResourceName, StorageS3CredentialsSchemaNextRotationAt),
+					resource.TestCheckResourceAttrWith(credentialsResourceName, StorageS3CredentialsSchemaAccessKey, func(value string) error {
+						accessKey = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: template("2"),
+				Check: resource.TestCheckResourceAttrWith(credentialsResourceName, StorageS3CredentialsSchemaAccessKey, func(value string) error {
+					if value == accessKey {
+						return fmt.Errorf("access key was not rotated")
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
+
+func TestStorageS3CredentialsRotationDue(t *testing.T) {
+	rotatedAt := "2024-01-01T00:00:00Z"
+	now := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		period string
+		want   bool
+	}{
+		{name: "no period", period: "", want: false},
+		{name: "not due", period: "721h", want: false},
+		{name: "due", period: "720h", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := storageS3CredentialsRotationDue(rotatedAt, tt.period, now); got != tt.want {
+				t.Errorf("storageS3CredentialsRotationDue(%q) = %v, want %v", tt.period, got, tt.want)
+			}
+		})
+	}
+	if got := storageS3CredentialsNextRotation(rotatedAt, "720h"); got != "2024-01-31T00:00:00Z" {
+		t.Errorf("next rotation = %s", got)
+	}
+}
@@ -0,0 +1,268 @@
+package gcore
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	StorageS3ObjectSchemaBucket        = "bucket"
+	StorageS3ObjectSchemaKey           = "key"
+	StorageS3ObjectSchemaSource        = "source"
+	StorageS3ObjectSchemaContent       = "content"
+	StorageS3ObjectSchemaContentBase64 = "content_base64"
+	StorageS3ObjectSchemaContentType   = "content_type"
+	StorageS3ObjectSchemaCacheControl  = "cache_control"
+	StorageS3ObjectSchemaSHA256        = "sha256"
+	StorageS3ObjectSchemaETag          = "etag"
+	StorageS3ObjectSchemaURL           = "url"
+
+	// storageS3ObjectSHA256Header is the object metadata holding the content checksum, for change detection
+	storageS3ObjectSHA256Header = "X-Amz-Meta-Sha256"
+)
+
+var storageS3ObjectSources = []string{StorageS3ObjectSchemaSource, StorageS3ObjectSchemaContent, StorageS3ObjectSchemaContentBase64}
+
+func resourceStorageS3Object() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			StorageS3BucketSchemaStorageID: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "An id of existing storage resource.",
+			},
+			StorageS3ObjectSchemaBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "A name of existing storage bucket.",
+			},
+			StorageS3ObjectSchemaKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 1024),
+				Description:  "Object key.",
+			},
+			StorageS3BucketSchemaAccessKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "S3 access key of the storage, e.g. generated_access_key of gcore_storage_s3.",
+			},
+			StorageS3BucketSchemaSecretKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "S3 secret key of the storage, e.g. generated_secret_key of gcore_storage_s3.",
+			},
+			StorageS3ObjectSchemaSource: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: storageS3ObjectSources,
+				Description:  "Path of the file to upload.",
+			},
+			StorageS3ObjectSchemaContent: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: storageS3ObjectSources,
+				Description:  "Object content, as a UTF-8 string.",
+			},
+			StorageS3ObjectSchemaContentBase64: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: storageS3ObjectSources,
+				ValidateFunc: validation.StringIsBase64,
+				Description:  "Base64-encoded object content, for binary data.",
+			},
+			StorageS3ObjectSchemaContentType: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Content type of the object, detected from the key extension if not set.",
+			},
+			StorageS3ObjectSchemaCacheControl: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Cache-Control header of the object.",
+			},
+			StorageS3ObjectSchemaSHA256: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 checksum of the content. A change of the content uploads the object again.",
+			},
+			StorageS3ObjectSchemaETag: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ETag of the object.",
+			},
+			StorageS3ObjectSchemaURL: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "HTTP URL of the object.",
+			},
+		},
+		CreateContext: resourceStorageS3ObjectPut,
+		ReadContext:   resourceStorageS3ObjectRead,
+		UpdateContext: resourceStorageS3ObjectUpdate,
+		DeleteContext: resourceStorageS3ObjectDelete,
+		CustomizeDiff: resourceStorageS3ObjectCustomizeDiff,
+		Description: "Represent s3 storage object resource, uploaded via the S3 API with the storage keys. " +
+			"The object is uploaded again when its content, content type or cache control change, " +
+			"or when it is changed outside of Terraform.",
+	}
+}
+
+// calculate content SHA-256 to detect content change
+func resourceStorageS3ObjectCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range storageS3ObjectSources {
+		if !diff.NewValueKnown(key) {
+			return diff.SetNewComputed(StorageS3ObjectSchemaSHA256)
+		}
+	}
+	payload, err := loadStorageS3ObjectContent(diff)
+	if err != nil {
+		return err
+	}
+	checksum := sha256Checksum(payload)
+	if checksum == diff.Get(StorageS3ObjectSchemaSHA256).(string) {
+		return nil
+	}
+	if err := diff.SetNew(StorageS3ObjectSchemaSHA256, checksum); err != nil {
+		return err
+	}
+	return diff.SetNewComputed(StorageS3ObjectSchemaETag)
+}
+
+func resourceStorageS3ObjectPut(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	storageId := d.Get(StorageS3BucketSchemaStorageID).(int)
+	bucket := d.Get(StorageS3ObjectSchemaBucket).(string)
+	key := d.Get(StorageS3ObjectSchemaKey).(string)
+	log.Printf("[DEBUG] Start S3 Storage Object Resource uploading (id=%d, bucket=%s, key=%s)\n", storageId, bucket, key)
+	defer log.Println("[DEBUG] Finish S3 Storage Object Resource uploading")
+
+	s3, err := storageS3ObjectClient(ctx, d, m.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	payload, err := loadStorageS3ObjectContent(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	contentType := d.Get(StorageS3ObjectSchemaContentType).(string)
+	if contentType == "" {
+		contentType = storageS3ObjectContentType(key)
+	}
+	header := http.Header{
+		"Content-Type":              {contentType},
+		storageS3ObjectSHA256Header: {sha256Checksum(payload)},
+	}
+	if cacheControl := d.Get(StorageS3ObjectSchemaCacheControl).(string); cacheControl != "" {
+		header.Set("Cache-Control", cacheControl)
+	}
+	if _, err := s3.putObject(ctx, bucket, key, header, payload); err != nil {
+		return diag.FromErr(fmt.Errorf("upload storage object: %w", err))
+	}
+	d.SetId(fmt.Sprintf("%d:%s:%s", storageId, bucket, key))
+
+	return resourceStorageS3ObjectRead(ctx, d, m)
+}
+
+func resourceStorageS3ObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	bucket := d.Get(StorageS3ObjectSchemaBucket).(string)
+	key := d.Get(StorageS3ObjectSchemaKey).(string)
+	log.Printf("[DEBUG] Start S3 Storage Object Resource reading (id=%s)\n", d.Id())
+	defer log.Println("[DEBUG] Finish S3 Storage Object Resource reading")
+
+	s3, err := storageS3ObjectClient(ctx, d, m.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	header, found, err := s3.headObject(ctx, bucket, key)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get storage object: %w", err))
+	}
+	if !found {
+		log.Printf("[WARN] S3 Storage Object %s/%s not found, removing from state\n", bucket, key)
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set(StorageS3ObjectSchemaContentType, header.Get("Content-Type"))
+	_ = d.Set(StorageS3ObjectSchemaCacheControl, header.Get("Cache-Control"))
+	// objects changed outside of Terraform have no or a different checksum and are uploaded again
+	_ = d.Set(StorageS3ObjectSchemaSHA256, header.Get(storageS3ObjectSHA256Header))
+	_ = d.Set(StorageS3ObjectSchemaETag, strings.Trim(header.Get("ETag"), `"`))
+	_ = d.Set(StorageS3ObjectSchemaURL, fmt.Sprintf("%s/%s/%s", s3.endpoint, bucket, s3URIEncode(key, false)))
+
+	return nil
+}
+
+func resourceStorageS3ObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChanges(append(storageS3ObjectSources, StorageS3ObjectSchemaContentType, StorageS3ObjectSchemaCacheControl, StorageS3ObjectSchemaSHA256)...) {
+		return resourceStorageS3ObjectRead(ctx, d, m)
+	}
+	return resourceStorageS3ObjectPut(ctx, d, m)
+}
+
+func resourceStorageS3ObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	bucket := d.Get(StorageS3ObjectSchemaBucket).(string)
+	key := d.Get(StorageS3ObjectSchemaKey).(string)
+	log.Printf("[DEBUG] Start S3 Storage Object Resource deleting (id=%s)\n", d.Id())
+	defer log.Println("[DEBUG] Finish S3 Storage Object Resource deleting")
+
+	s3, err := storageS3ObjectClient(ctx, d, m.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := s3.deleteObject(ctx, bucket, key); err != nil {
+		return diag.FromErr(fmt.Errorf("delete storage object: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func storageS3ObjectClient(ctx context.Context, d *schema.ResourceData, config *Config) (*s3Client, error) {
+	return newStorageS3Client(ctx, config, d.Get(StorageS3BucketSchemaStorageID).(int),
+		d.Get(StorageS3BucketSchemaAccessKey).(string), d.Get(StorageS3BucketSchemaSecretKey).(string))
+}
+
+// loadStorageS3ObjectContent reads the object content from the configured source
+func loadStorageS3ObjectContent(d interface{ Get(string) interface{} }) ([]byte, error) {
+	if source := d.Get(StorageS3ObjectSchemaSource).(string); source != "" {
+		payload, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("opening file %s: %w", source, err)
+		}
+		return payload, nil
+	}
+	if content := d.Get(StorageS3ObjectSchemaContentBase64).(string); content != "" {
+		payload, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return nil, fmt.Errorf("decoding content_base64: %w", err)
+		}
+		return payload, nil
+	}
+	return []byte(d.Get(StorageS3ObjectSchemaContent).(string)), nil
+}
+
+func storageS3ObjectContentType(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
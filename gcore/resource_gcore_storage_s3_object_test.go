@@ -0,0 +1,78 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccStorageS3Object(t *testing.T) {
+
+	random := time.Now().Nanosecond()
+	storageResourceName := fmt.Sprintf("gcore_storage_s3.terraform_test_%d_s3", random)
+	objectResourceName := fmt.Sprintf("gcore_storage_s3_object.terraform_test_%d_s3_object", random)
+	objectsDataSourceName := fmt.Sprintf("data.gcore_storage_s3_objects.terraform_test_%d_s3_objects", random)
+
+	template := func(content string) string {
+		return fmt.Sprintf(`
+resource "gcore_storage_s3" "terraform_test_%[1]d_s3" {
+  name = "terraform_test_%[1]d"
+  location = "s-region-1"
+}
+
+resource "gcore_storage_s3_bucket" "terraform_test_%[1]d_s3_bucket" {
+  name = "terraform_test_%[1]d"
+  storage_id = %[2]s.id
+}
+
+resource "gcore_storage_s3_object" "terraform_test_%[1]d_s3_object" {
+  storage_id = %[2]s.id
+  bucket = gcore_storage_s3_bucket.terraform_test_%[1]d_s3_bucket.name
+  key = "config/app.json"
+  content = %[3]q
+  cache_control = "no-cache"
+  access_key = %[2]s.generated_access_key
+  secret_key = %[2]s.generated_secret_key
+}
+
+data "gcore_storage_s3_objects" "terraform_test_%[1]d_s3_objects" {
+  storage_id = %[2]s.id
+  bucket = gcore_storage_s3_object.terraform_test_%[1]d_s3_object.bucket
+  prefix = "config/"
+  access_key = %[2]s.generated_access_key
+  secret_key = %[2]s.generated_secret_key
+}
+		`, random, storageResourceName, content)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckVars(t, GCORE_USERNAME_VAR, GCORE_PASSWORD_VAR, GCORE_STORAGE_URL_VAR)
+		},
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: template(`{"version": 1}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(objectResourceName),
+					resource.TestCheckResourceAttr(objectResourceName, StorageS3ObjectSchemaContentType, "application/json"),
+					resource.TestCheckResourceAttr(objectResourceName, StorageS3ObjectSchemaSHA256, sha256Checksum([]byte(`{"version": 1}`))),
+					resource.TestCheckResourceAttr(objectsDataSourceName, "keys.#", "1"),
+					resource.TestCheckResourceAttr(objectsDataSourceName, "keys.0", "config/app.json"),
+				),
+			},
+			{
+				Config: template(`{"version": 2}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(objectResourceName, StorageS3ObjectSchemaSHA256, sha256Checksum([]byte(`{"version": 2}`))),
+					resource.TestCheckResourceAttr(objectsDataSourceName, "objects.0.size", "14"),
+				),
+			},
+		},
+	})
+}
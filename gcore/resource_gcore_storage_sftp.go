@@ -14,6 +14,7 @@ import (
 	"github.com/G-Core/gcore-storage-sdk-go/swagger/client/storage"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -24,6 +25,8 @@ const (
 	StorageSFTPSchemaKeyId                = "ssh_key_id"
 	StorageSFTPSchemaExpires              = "http_expires_header_value"
 	StorageSFTPSchemaServerAlias          = "http_servername_alias"
+	StorageSFTPSchemaGeneratedPassword    = "generated_password_value"
+	StorageSFTPSchemaPasswordRotation     = "password_rotation_triggers"
 
 	StorageSFTPSchemaUpdateAfterCreate = "update_after_create"
 )
@@ -85,7 +88,7 @@ func resourceStorageSFTP() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "A location of new storage resource.",
+				Description: "A location of new storage resource, checked at plan time against sftp locations of gcore_storage_locations.",
 			},
 			StorageSFTPSchemaSftpPassword: {
 				Type:     schema.TypeString,
@@ -104,6 +107,18 @@ func resourceStorageSFTP() *schema.Resource {
 				Optional:    true,
 				Description: "An auto generated sftp password for new storage resource.",
 			},
+			StorageSFTPSchemaGeneratedPassword: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "A sftp password generated with generated_password, on creation or rotation.",
+			},
+			StorageSFTPSchemaPasswordRotation: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values, a new sftp password is generated when any of them changes. Requires generated_password.",
+			},
 			StorageSFTPSchemaKeyId: {
 				Type: schema.TypeList,
 				Elem: &schema.Schema{
@@ -129,13 +144,53 @@ func resourceStorageSFTP() *schema.Resource {
 		ReadContext:   resourceStorageSFTPRead,
 		UpdateContext: resourceStorageSFTPUpdate,
 		DeleteContext: resourceStorageSFTPDelete,
-		Description:   "Represent sftp storage resource. https://storage.gcore.com/storage/list",
+		CustomizeDiff: customdiff.All(resourceStorageSFTPCustomizeDiff, validateStorageLocationDiff("sftp")),
+		Description: "Represent sftp storage resource. https://storage.gcore.com/storage/list\n\n" +
+			"With generated_password, the generated password is stored in generated_password_value. " +
+			"Earlier provider versions stored it in password, it is moved to generated_password_value on the next apply " +
+			"unless password is set in the configuration, and configurations reading it from password need to use generated_password_value instead.",
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
+// check that rotation triggers are used with a generated password and plan a new one when they change
+func resourceStorageSFTPCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	triggers := diff.Get(StorageSFTPSchemaPasswordRotation).(map[string]interface{})
+	if len(triggers) > 0 && diff.NewValueKnown(StorageSFTPSchemaGenerateSftpPassword) &&
+		!diff.Get(StorageSFTPSchemaGenerateSftpPassword).(bool) {
+		return fmt.Errorf("%s requires %s", StorageSFTPSchemaPasswordRotation, StorageSFTPSchemaGenerateSftpPassword)
+	}
+	if pass, ok := storageSFTPLegacyGeneratedPassword(diff); ok {
+		if err := diff.SetNew(StorageSFTPSchemaGeneratedPassword, pass); err != nil {
+			return err
+		}
+	}
+	if diff.Id() == "" || !diff.HasChange(StorageSFTPSchemaPasswordRotation) {
+		return nil
+	}
+	return diff.SetNewComputed(StorageSFTPSchemaGeneratedPassword)
+}
+
+// storageSFTPLegacyGeneratedPassword returns the generated password earlier versions kept in password.
+// It is moved to generated_password_value only when password is not set in the configuration,
+// a configured password is the user's own and stays where it is.
+func storageSFTPLegacyGeneratedPassword(diff *schema.ResourceDiff) (string, bool) {
+	if diff.Id() == "" || !diff.Get(StorageSFTPSchemaGenerateSftpPassword).(bool) {
+		return "", false
+	}
+	if generated, _ := diff.GetChange(StorageSFTPSchemaGeneratedPassword); generated.(string) != "" {
+		return "", false
+	}
+	config := diff.GetRawConfig()
+	if config.IsNull() || !config.GetAttr(StorageSFTPSchemaSftpPassword).IsNull() {
+		return "", false
+	}
+	pass, _ := diff.GetChange(StorageSFTPSchemaSftpPassword)
+	return pass.(string), pass.(string) != ""
+}
+
 func resourceStorageValidateKeys(ctx context.Context, sdk *gstorage.SDK, d *schema.ResourceData) error {
 	keyIds := d.Get(StorageSFTPSchemaKeyId).([]interface{})
 	if len(keyIds) == 0 {
@@ -269,7 +324,12 @@ func resourceStorageSFTPCreate(ctx context.Context, d *schema.ResourceData, m in
 	d.SetId(fmt.Sprintf("%d", result.ID))
 	*id = int(result.ID)
 	if result.Credentials.SftpPassword != "" {
-		_ = d.Set(StorageSFTPSchemaSftpPassword, result.Credentials.SftpPassword)
+		// generated password is kept apart from password, which would otherwise be removed on the next apply
+		if d.Get(StorageSFTPSchemaGenerateSftpPassword).(bool) {
+			_ = d.Set(StorageSFTPSchemaGeneratedPassword, result.Credentials.SftpPassword)
+		} else {
+			_ = d.Set(StorageSFTPSchemaSftpPassword, result.Credentials.SftpPassword)
+		}
 	}
 
 	err = resourceStorageLinkKeys(ctx, client, d, result.ID)
@@ -385,7 +445,10 @@ func resourceStorageSFTPUpdate(ctx context.Context, d *schema.ResourceData, m in
 		_ = d.Set(StorageSFTPSchemaUpdateAfterCreate, false)
 		return nil
 	}
-	if d.HasChange(StorageSFTPSchemaSftpPassword) {
+	oldPass, _ := d.GetChange(StorageSFTPSchemaSftpPassword)
+	// a password moved to generated_password_value by the plan is still the storage's password
+	movedPass := oldPass.(string) != "" && oldPass.(string) == d.Get(StorageSFTPSchemaGeneratedPassword).(string)
+	if d.HasChange(StorageSFTPSchemaSftpPassword) && !movedPass {
 		pass := d.Get(StorageSFTPSchemaSftpPassword).(string)
 		deletePass := false
 		if pass == "" {
@@ -404,6 +467,17 @@ func resourceStorageSFTPUpdate(ctx context.Context, d *schema.ResourceData, m in
 			return diag.FromErr(fmt.Errorf("update creds: %w", err))
 		}
 	}
+	if d.HasChange(StorageSFTPSchemaPasswordRotation) && d.Get(StorageSFTPSchemaGenerateSftpPassword).(bool) {
+		creds, err := client.UpdateStorageCredentials(func(params *storage.StorageUpdateCredentialsHTTPParams) {
+			params.ID = id
+			params.Context = ctx
+			params.Body.GenerateSftpPassword = true
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("rotate password: %w", err))
+		}
+		_ = d.Set(StorageSFTPSchemaGeneratedPassword, creds.SftpPassword)
+	}
 	err = resourceStorageRelinkKeys(ctx, client, d, id)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("update keys: %w", err))
@@ -0,0 +1,60 @@
+package gcore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceStorageSFTPLegacyGeneratedPassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		password      string
+		wantGenerated bool
+	}{
+		{name: "generated password moved", wantGenerated: true},
+		{name: "configured password kept", password: "legacy-password"},
+	}
+
+	storageResource := resourceStorageSFTP()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":               "storage",
+				"location":           "mia",
+				"generated_password": true,
+			}
+			rawPassword := cty.NullVal(cty.String)
+			if tt.password != "" {
+				raw["password"] = tt.password
+				rawPassword = cty.StringVal(tt.password)
+			}
+			state := &terraform.InstanceState{
+				ID: "1",
+				Attributes: map[string]string{
+					"id":                 "1",
+					"name":               "storage",
+					"location":           "mia",
+					"generated_password": "true",
+					"password":           "legacy-password",
+				},
+				RawConfig: cty.ObjectVal(map[string]cty.Value{"password": rawPassword}),
+			}
+
+			diff, err := storageResource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var generated *terraform.ResourceAttrDiff
+			ok := false
+			if diff != nil {
+				generated, ok = diff.Attributes[StorageSFTPSchemaGeneratedPassword]
+			}
+			if ok != tt.wantGenerated || (ok && generated.New != "legacy-password") {
+				t.Errorf("generated password diff = %+v", generated)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package gcore
 
 import (
 	"context"
+	"crypto/rsa"
 	"fmt"
 	"log"
 	"regexp"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
+	"golang.org/x/crypto/ssh"
 
 	"github.com/G-Core/gcore-storage-sdk-go/swagger/client/key"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,6 +21,8 @@ const (
 	StorageKeySchemaKey  = "key"
 	StorageKeySchemaName = "name"
 	StorageKeySchemaId   = "key_id"
+
+	storageKeyMinRSABits = 2048
 )
 
 func resourceStorageSFTPKey() *schema.Resource {
@@ -38,10 +42,16 @@ func resourceStorageSFTPKey() *schema.Resource {
 				Description: "A name of new storage key resource.",
 			},
 			StorageKeySchemaKey: {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "A body of of new storage key resource.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
+					if err := validateStorageSSHKey(i.(string)); err != nil {
+						return diag.FromErr(err)
+					}
+					return nil
+				},
+				Description: fmt.Sprintf("A body of of new storage key resource, an OpenSSH public key: ed25519, ECDSA or RSA of at least %d bits.", storageKeyMinRSABits),
 			},
 			StorageKeySchemaId: {
 				Type:        schema.TypeInt,
@@ -164,3 +174,28 @@ func storageKeyResourceID(d *schema.ResourceData) string {
 	}
 	return resourceID
 }
+
+// validateStorageSSHKey checks that key is a single OpenSSH public key of a supported type and size
+func validateStorageSSHKey(key string) error {
+	pub, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return fmt.Errorf("key is not a valid OpenSSH public key: %w", err)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return fmt.Errorf("key must contain a single public key")
+	}
+	switch pub.Type() {
+	case ssh.KeyAlgoED25519, ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521:
+		return nil
+	case ssh.KeyAlgoRSA:
+		rsaKey, ok := pub.(ssh.CryptoPublicKey).CryptoPublicKey().(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key is not a valid RSA public key")
+		}
+		if bits := rsaKey.N.BitLen(); bits < storageKeyMinRSABits {
+			return fmt.Errorf("RSA key is %d bits, at least %d bits are required", bits, storageKeyMinRSABits)
+		}
+		return nil
+	}
+	return fmt.Errorf("key type %s is not supported, use ed25519, ECDSA or RSA", pub.Type())
+}
@@ -4,11 +4,18 @@
 package gcore
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestAccStorageKey(t *testing.T) {
@@ -43,3 +50,43 @@ resource "gcore_storage_sftp_key" "%s" {
 		},
 	})
 }
+
+func TestValidateStorageSSHKey(t *testing.T) {
+	authorizedKey := func(key interface{}) string {
+		pub, err := ssh.NewPublicKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(ssh.MarshalAuthorizedKey(pub))
+	}
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 1024)
+
+	tests := []struct {
+		name    string
+		key     string
+		wantErr string
+	}{
+		{name: "ed25519", key: strings.TrimSpace(authorizedKey(edKey)) + " user@host"},
+		{name: "ecdsa", key: authorizedKey(&ecKey.PublicKey)},
+		{name: "rsa 2048", key: "ssh-rsa AAAAB3NzaC1yc2EAAAABIwAAAQEAklOUpkDHrfHY17SbrmTIpNLTGK9Tjom/BWDSUGPl+nafzlHDTYW7hdI4yZ5ew18JH4JW9jbhUFrviQzM7xlELEVf4h9lFX5QVkbPppSwg0cda3Pbv7kOdJ/MTyBlWXFCR+HAo3FXRitBqxiX1nKhXpHAZsMciLq8V6RjsNAQwdsdMFvSlVK/7XAt3FaoJoAsncM1Q9x5+3V0Ww68/eIFmb1zuUFljQJKprrX88XypNDvjYNby6vw/Pb0rwert/EnmZ+AW4OZPnTPI89ZPmVMLuayrD2cE86Z/il8b+gw3r3+1nKatmIkjn2so1d01QraTlMqVSsbxNrRFi9wrf+M7Q== schacon@mylaptop.local"},
+		{name: "rsa 1024", key: authorizedKey(&rsaKey.PublicKey), wantErr: "RSA key is 1024 bits"},
+		{name: "two keys", key: authorizedKey(edKey) + authorizedKey(edKey), wantErr: "single public key"},
+		{name: "malformed", key: "ssh-ed25519 AAAA", wantErr: "not a valid OpenSSH public key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStorageSSHKey(tt.key)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
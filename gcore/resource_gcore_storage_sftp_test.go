@@ -39,6 +39,20 @@ resource "gcore_storage_sftp" "terraformtest%d_sftp" {
 		`, random, random, alias)
 	}
 
+	templateRotatePassword := func() string {
+		return fmt.Sprintf(`
+resource "gcore_storage_sftp" "terraformtest%d_sftp" {
+  name = "terraformtest%d"
+  location = "mia"
+  http_servername_alias = "%s"
+  generated_password = true
+  password_rotation_triggers = {
+    rotation = "1"
+  }
+}
+		`, random, random, alias)
+	}
+
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheckVars(t, GCORE_USERNAME_VAR, GCORE_PASSWORD_VAR, GCORE_STORAGE_URL_VAR)
@@ -85,6 +99,13 @@ resource "gcore_storage_sftp" "terraformtest%d_sftp" {
 					resource.TestCheckResourceAttr(resourceName, StorageSFTPSchemaServerAlias, alias),
 				),
 			},
+			{
+				Config: templateRotatePassword(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, StorageSFTPSchemaGeneratedPassword),
+				),
+			},
 		},
 	})
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils"
@@ -12,15 +14,21 @@ import (
 	metadatav1 "github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	volumesV2 "github.com/G-Core/gcorelabscloud-go/gcore/volume/v2/volumes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const volumeDeleting int = 1200
 const volumeCreatingTimeout int = 1200
 const volumeExtending int = 1200
+const volumeReverting int = 1200
+const volumeRetyping int = 1200
 const volumesPoint = "volumes"
 
 func resourceVolume() *schema.Resource {
@@ -29,6 +37,7 @@ func resourceVolume() *schema.Resource {
 		ReadContext:   resourceVolumeRead,
 		UpdateContext: resourceVolumeUpdate,
 		DeleteContext: resourceVolumeDelete,
+		CustomizeDiff: resourceVolumeCustomizeDiff,
 		Description:   "Represent volume. A volume is a file storage which is similar to SSD and HDD hard disks but located in the cloud",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -99,14 +108,49 @@ func resourceVolume() *schema.Resource {
 				Required: true,
 			},
 			"size": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Size of the volume in GB. The size can only be increased",
 			},
 			"type_name": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(volumes.VolumeType("").StringList(), false),
+				Description: "Available value is 'standard', 'ssd_hiiops', 'cold', 'ultra'. Defaults to standard. " +
+					"Changing the type migrates the volume in place, the new type must be available in the region",
+			},
+			"detach_on_resize": &schema.Schema{
+				Type:     schema.TypeBool,
 				Optional: true,
-				// todo add validation
-				Description: "Available value is 'standard', 'ssd_hiiops', 'cold', 'ultra'. Defaults to standard",
+				Default:  false,
+				Description: "Allow detaching the volume from its instances when it can't be extended while attached. " +
+					"The volume is attached back after the resize",
+			},
+			"attachments": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Instances the volume is attached to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Device name of the volume in the instance, e.g. /dev/vdb",
+						},
+						"attached_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"image_id": &schema.Schema{
 				Type:        schema.TypeString,
@@ -120,6 +164,12 @@ func resourceVolume() *schema.Resource {
 				ForceNew:    true,
 				Description: "Mandatory if volume is created from a snapshot",
 			},
+			"revert_to_snapshot_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Revert the volume in place to the snapshot whenever the value changes. " +
+					"Only the latest snapshot of the volume can be used",
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -253,6 +303,9 @@ func resourceVolumeRead(ctx context.Context, d *schema.ResourceData, m interface
 	d.Set("type_name", volume.VolumeType)
 	d.Set("region_id", volume.RegionID)
 	d.Set("project_id", volume.ProjectID)
+	if err = d.Set("attachments", flattenVolumeAttachments(volume.Attachments)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	metadataMap, metadataReadOnly := PrepareMetadata(volume.Metadata)
 
@@ -287,6 +340,16 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("revert_to_snapshot_id") {
+		if snapshotID := d.Get("revert_to_snapshot_id").(string); snapshotID != "" {
+			if err := RevertVolume(provider, d, volumeID, snapshotID); err != nil {
+				// keep the previous value so that the revert is retried on the next apply
+				d.Partial(true)
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	if d.HasChange("name") {
 		name := d.Get("name").(string)
 		_, err := volumes.Update(client, volumeID, volumes.UpdateOpts{Name: name}).Extract()
@@ -300,7 +363,7 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		newSize := newValue.(int)
 		if newSize != 0 {
 			if volume.Size < newSize {
-				err = ExtendVolume(client, volumeID, newSize)
+				err = extendAttachedVolume(provider, d, client, volume, newSize)
 				if err != nil {
 					return diag.FromErr(err)
 				}
@@ -310,9 +373,8 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
-	if d.HasChange("type_name") {
-		newTN := d.Get("type_name")
-		newVolumeType, err := volumes.VolumeType(newTN.(string)).ValidOrNil()
+	if newTN := d.Get("type_name").(string); d.HasChange("type_name") && newTN != "" {
+		newVolumeType, err := volumes.VolumeType(newTN).ValidOrNil()
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -322,7 +384,20 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 		_, err = volumes.Retype(client, volumeID, opts).Extract()
 		if err != nil {
-			return diag.FromErr(err)
+			oldTN, _ := d.GetChange("type_name")
+			return diag.Errorf("cannot retype volume %s from %s to %s: %s", volumeID, oldTN, newTN, err)
+		}
+
+		retypeWaitConf := retry.StateChangeConf{
+			Pending:    []string{volumes.Retyping.String()},
+			Target:     []string{volumes.Available.String(), volumes.InUse.String()},
+			Refresh:    volumeRetypeRefreshedFunc(client, volumeID, *newVolumeType),
+			Timeout:    time.Duration(volumeRetyping) * time.Second,
+			Delay:      5 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+		if _, err = retypeWaitConf.WaitForStateContext(ctx); err != nil {
+			return diag.Errorf("Error waiting for volume (%s) retype to %s: %s", volumeID, newTN, err)
 		}
 	}
 
@@ -455,3 +530,178 @@ func ExtendVolume(client *gcorecloud.ServiceClient, volumeID string, newSize int
 	log.Printf("[DEBUG] Finish waiting.")
 	return nil
 }
+
+// RevertVolume reverts the volume to its latest snapshot, which must be snapshotID
+func RevertVolume(provider *gcorecloud.ProviderClient, d *schema.ResourceData, volumeID, snapshotID string) error {
+	snapshotsClient, err := CreateClient(provider, d, snapshotsPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+	latest, err := latestVolumeSnapshot(snapshotsClient, volumeID)
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		return fmt.Errorf("cannot revert volume %s to snapshot %s: the volume has no snapshots", volumeID, snapshotID)
+	}
+	if latest.ID != snapshotID {
+		return fmt.Errorf("cannot revert volume %s to snapshot %s: only the latest snapshot %s of the volume can be used",
+			volumeID, snapshotID, latest.ID)
+	}
+
+	client, err := CreateClient(provider, d, volumesPoint, versionPointV1)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] Reverting volume %s to snapshot %s", volumeID, snapshotID)
+	if err := waitForTaskResult(volumes.Revert(client, volumeID), volumeReverting, provider, d); err != nil {
+		return fmt.Errorf("cannot revert volume %s to snapshot %s: %w", volumeID, snapshotID, err)
+	}
+	log.Printf("[DEBUG] Finish waiting.")
+	return nil
+}
+
+func resourceVolumeCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	if diff.HasChange("size") {
+		oldSize, newSize := diff.GetChange("size")
+		if newSize.(int) != 0 && newSize.(int) < oldSize.(int) {
+			return fmt.Errorf("volume size cannot be reduced from %d GB to %d GB, volumes can only be extended", oldSize.(int), newSize.(int))
+		}
+	}
+	if diff.HasChange("type_name") && diff.NewValueKnown("type_name") {
+		oldType, newType := diff.GetChange("type_name")
+		regionID := diff.Get("region_id").(int)
+		config, ok := m.(*Config)
+		if oldType.(string) != "" && newType.(string) != "" && regionID != 0 && ok && config.Provider != nil {
+			client, err := gc.ClientServiceFromProvider(config.Provider, gcorecloud.EndpointOpts{
+				Name:    regionPoint,
+				Version: versionPointV1,
+			})
+			if err != nil {
+				return err
+			}
+			if err := checkVolumeRetype(client, regionID, oldType.(string), newType.(string)); err != nil {
+				return err
+			}
+		}
+	}
+	// a detached volume may get another device when it is attached back
+	if diff.HasChange("size") && diff.Get("detach_on_resize").(bool) && len(diff.Get("attachments").([]interface{})) > 0 {
+		return diff.SetNewComputed("attachments")
+	}
+	return nil
+}
+
+// checkVolumeRetype checks that the new volume type is available in the region of the volume
+func checkVolumeRetype(client *gcorecloud.ServiceClient, regionID int, oldType, newType string) error {
+	available, err := getRegionVolumeTypes(client, regionID)
+	if err != nil {
+		return fmt.Errorf("cannot check the retype of volume from %s to %s, cannot get volume types of region %d: %w",
+			oldType, newType, regionID, err)
+	}
+	if !slices.Contains(available, newType) {
+		return fmt.Errorf("volume cannot be retyped from %s to %s, %s is not available in region %d, available types: %s",
+			oldType, newType, newType, regionID, strings.Join(available, ", "))
+	}
+	return nil
+}
+
+// volumeRetypeRefreshedFunc reports the volume as retyping until it has the new type,
+// so that the wait doesn't end before the retype starts
+func volumeRetypeRefreshedFunc(client *gcorecloud.ServiceClient, volumeID string, volumeType volumes.VolumeType) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		volume, err := volumes.Get(client, volumeID).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		if volume.VolumeType != volumeType && (volume.Status == volumes.Available || volume.Status == volumes.InUse) {
+			return volume, volumes.Retyping.String(), nil
+		}
+		return volume, volume.Status.String(), nil
+	}
+}
+
+// isVolumeInUseError reports whether the volume operation was rejected because the volume is attached
+func isVolumeInUseError(err error) bool {
+	var errDefault409 gcorecloud.ErrDefault409
+	if errors.As(err, &errDefault409) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "in-use") || strings.Contains(msg, "in use")
+}
+
+// extendAttachedVolume extends the volume, detaching it from its instances for the resize
+// when it can't be extended while attached and detach_on_resize is set
+func extendAttachedVolume(provider *gcorecloud.ProviderClient, d *schema.ResourceData, client *gcorecloud.ServiceClient, volume *volumes.Volume, newSize int) error {
+	err := ExtendVolume(client, volume.ID, newSize)
+	if err == nil || len(volume.Attachments) == 0 || volume.Status != volumes.InUse || !isVolumeInUseError(err) {
+		return err
+	}
+
+	instanceIDs := make([]string, len(volume.Attachments))
+	for i, attachment := range volume.Attachments {
+		instanceIDs[i] = attachment.ServerID
+	}
+	if !d.Get("detach_on_resize").(bool) {
+		return fmt.Errorf("cannot extend volume %s attached to instances %s: %w. "+
+			"Set detach_on_resize = true to detach the volume during the resize, or stop the instances",
+			volume.ID, strings.Join(instanceIDs, ", "), err)
+	}
+	log.Printf("[DEBUG] Extending volume %s failed while attached (%s), detaching it from %s", volume.ID, err, instanceIDs)
+
+	clientV2, err := CreateClient(provider, d, volumesPoint, versionPointV2)
+	if err != nil {
+		return err
+	}
+	detached := make([]string, 0, len(instanceIDs))
+	var resizeErr error
+	for _, instanceID := range instanceIDs {
+		results, err := volumesV2.Detach(clientV2, volume.ID, volumes.InstanceOperationOpts{InstanceID: instanceID}).Extract()
+		if err == nil && len(results.Tasks) == 0 {
+			err = errors.New("no task IDs returned")
+		}
+		if err == nil {
+			err = waitInstanceOperation(client, results.Tasks[0])
+		}
+		if err != nil {
+			resizeErr = fmt.Errorf("cannot detach volume %s from instance %s: %w", volume.ID, instanceID, err)
+			break
+		}
+		detached = append(detached, instanceID)
+	}
+	if resizeErr == nil {
+		resizeErr = ExtendVolume(client, volume.ID, newSize)
+	}
+
+	// attach the volume back even if the resize failed
+	for _, instanceID := range detached {
+		results, err := volumesV2.Attach(clientV2, volume.ID, volumes.InstanceOperationOpts{InstanceID: instanceID}).Extract()
+		if err == nil && len(results.Tasks) == 0 {
+			err = errors.New("no task IDs returned")
+		}
+		if err == nil {
+			err = waitInstanceOperation(client, results.Tasks[0])
+		}
+		if err != nil {
+			return errors.Join(resizeErr, fmt.Errorf("cannot attach volume %s back to instance %s: %w", volume.ID, instanceID, err))
+		}
+	}
+	return resizeErr
+}
+
+func flattenVolumeAttachments(attachments []volumes.Attachment) []map[string]interface{} {
+	flat := make([]map[string]interface{}, len(attachments))
+	for i, attachment := range attachments {
+		flat[i] = map[string]interface{}{
+			"instance_id":   attachment.ServerID,
+			"instance_name": attachment.InstanceName,
+			"device":        attachment.Device,
+			"attached_at":   attachment.AttachedAt.String(),
+		}
+	}
+	return flat
+}
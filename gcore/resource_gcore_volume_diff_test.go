@@ -0,0 +1,104 @@
+package gcore
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceVolumeCustomizeDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "726ecfcc-7fd0-4e30-a86e-7892524aa483",
+		Attributes: map[string]string{
+			"id":         "726ecfcc-7fd0-4e30-a86e-7892524aa483",
+			"name":       "volume",
+			"project_id": "1",
+			"region_id":  "1",
+			"size":       "10",
+			"type_name":  "standard",
+		},
+	}
+	tests := []struct {
+		name        string
+		size        int
+		typeName    string
+		wantErr     string
+		wantReplace bool
+	}{
+		{name: "extend", size: 20, typeName: "standard"},
+		{name: "shrink", size: 5, typeName: "standard", wantErr: "volume size cannot be reduced from 10 GB to 5 GB"},
+		{name: "retype in place", size: 10, typeName: "ssd_hiiops"},
+		{name: "retype to cold in place", size: 10, typeName: "cold"},
+	}
+
+	volumeResource := resourceVolume()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":       "volume",
+				"project_id": 1,
+				"region_id":  1,
+				"size":       tt.size,
+				"type_name":  tt.typeName,
+			})
+			diff, err := volumeResource.Diff(context.Background(), state, config, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff.RequiresNew() != tt.wantReplace {
+				t.Errorf("RequiresNew = %v, want %v", diff.RequiresNew(), tt.wantReplace)
+			}
+		})
+	}
+}
+
+func TestCheckVolumeRetype(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture apiFixture
+		newType string
+		wantErr string
+	}{
+		{
+			name:    "available",
+			fixture: apiFixture{status: http.StatusOK, response: `{"id":76,"available_volume_types":["standard","cold"]}`},
+			newType: "cold",
+		},
+		{
+			name:    "missing from region",
+			fixture: apiFixture{status: http.StatusOK, response: `{"id":76,"available_volume_types":["standard","ssd_hiiops"]}`},
+			newType: "cold",
+			wantErr: "cold is not available in region 76, available types: standard, ssd_hiiops",
+		},
+		{
+			name:    "region lookup failed",
+			fixture: apiFixture{status: http.StatusInternalServerError, response: `{"message":"internal error"}`},
+			newType: "cold",
+			wantErr: "cannot get volume types of region 76",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fixture.method, tt.fixture.path = http.MethodGet, "/76"
+			client := newAPIFixtureClient(t, tt.fixture)
+
+			err := checkVolumeRetype(client, 76, "standard", tt.newType)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package gcore
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExtendAttachedVolumeOtherError(t *testing.T) {
+	volumeID := "726ecfcc-7fd0-4e30-a86e-7892524aa483"
+	// the extend is rejected for another reason than the attachment, so the volume is not detached
+	client := newAPIFixtureClient(t, apiFixture{
+		method: http.MethodPost, path: "/" + volumeID + "/extend", request: `{"size":20}`,
+		status: http.StatusBadRequest, response: `{"message":"quota exceeded"}`,
+	})
+	d := schema.TestResourceDataRaw(t, resourceVolume().Schema, map[string]interface{}{
+		"detach_on_resize": true,
+	})
+	volume := &volumes.Volume{
+		ID:          volumeID,
+		Status:      volumes.InUse,
+		Attachments: []volumes.Attachment{{ServerID: "8dc30d49-bb34-4920-9bbd-03a2587ec0ad"}},
+	}
+
+	err := extendAttachedVolume(&gcorecloud.ProviderClient{}, d, client, volume, 20)
+	var errDefault400 gcorecloud.ErrDefault400
+	if !errors.As(err, &errDefault400) {
+		t.Errorf("expected the extend error unchanged, got %v", err)
+	}
+}
@@ -7,14 +7,23 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	waap "github.com/G-Core/gcore-waap-sdk-go"
 )
 
+const (
+	waapDomainCreateTimeout = 20 * time.Minute
+
+	waapDomainStatePresent = "present"
+	waapDomainStateAbsent  = "absent"
+)
+
 func resourceWaapDomain() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWaapDomainCreate,
@@ -22,13 +31,27 @@ func resourceWaapDomain() *schema.Resource {
 		UpdateContext: resourceWaapDomainUpdate,
 		DeleteContext: resourceWaapDomainDelete,
 		Description:   "Represent WAAP domain",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waapDomainCreateTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the domain.",
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "cdn_resource_id"},
+				Description:  "Name of the domain. Either `name` or `cdn_resource_id` must be specified.",
+				ForceNew:     true,
+			},
+			"cdn_resource_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "cdn_resource_id"},
+				Description: "ID of the CDN resource to protect. WAAP is turned on and off by the `waap` option of the " +
+					"`gcore_cdn_resource`, which must be enabled. The domain is awaited on create and its name is taken " +
+					"from the CDN resource CNAME.",
 			},
 			"status": {
 				Type:     schema.TypeString,
@@ -142,25 +165,39 @@ func resourceWaapDomain() *schema.Resource {
 }
 
 func resourceWaapDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Config).WaapClient
-	domainName := d.Get("name").(string)
-	params := waap.GetDomainsV1DomainsGetParams{
-		Name: &domainName,
-	}
+	config := m.(*Config)
+	client := config.WaapClient
 
-	resp, err := client.GetDomainsV1DomainsGetWithResponse(ctx, &params)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error listing domains: %v", err))
-	}
+	var domain *waap.SummaryDomainResponse
+	if cdnResourceID, ok := d.GetOk("cdn_resource_id"); ok {
+		// The waap option of the CDN resource onboards the domain, only wait for it here
+		domainName, enabled, err := getCDNResourceWaap(ctx, config, int64(cdnResourceID.(int)))
+		if err != nil {
+			return diag.Errorf("Failed to read CDN resource %d: %s", cdnResourceID.(int), err)
+		}
+		if !enabled {
+			return diag.Errorf("WAAP is not enabled on CDN resource %d, set `waap { value = true }` in the options "+
+				"of its gcore_cdn_resource", cdnResourceID.(int))
+		}
 
-	if resp.StatusCode() != http.StatusOK {
-		return diag.Errorf("Failed to read Domains. Status code: %d with error: %s", resp.StatusCode(), resp.Body)
-	}
+		domain, err = waitForWaapDomain(ctx, client, domainName, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.Errorf("Error waiting for WAAP domain '%s' to appear: %s", domainName, err)
+		}
 
-	domain := findDomainByName(*resp.JSON200, domainName)
+		d.Set("name", domain.Name)
+	} else {
+		domainName := d.Get("name").(string)
+
+		var err error
+		domain, err = getDomainByName(ctx, client, domainName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-	if domain == nil {
-		return diag.Errorf("Domain with name '%s' not found.", domainName)
+		if domain == nil {
+			return diag.Errorf("Domain with name '%s' not found.", domainName)
+		}
 	}
 
 	status := string(domain.Status)
@@ -252,6 +289,10 @@ func resourceWaapDomainRead(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.Errorf("Failed to read Domain details. Status code: %d with error: %s", resp.StatusCode(), resp.Body)
 	}
 
+	// Domain names are matched case-insensitively, keep the configured spelling
+	if !strings.EqualFold(d.Get("name").(string), resp.JSON200.Name) {
+		d.Set("name", resp.JSON200.Name)
+	}
 	d.Set("status", string(resp.JSON200.Status))
 
 	// Get domain settings
@@ -410,9 +451,73 @@ func resourceWaapDomainUpdate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceWaapDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Domains are left untouched, protection of CDN-onboarded domains ends with the waap option of the CDN resource
 	return nil
 }
 
+func getDomainByName(ctx context.Context, client *waap.ClientWithResponses, name string) (*waap.SummaryDomainResponse, error) {
+	params := waap.GetDomainsV1DomainsGetParams{
+		Name: &name,
+	}
+
+	resp, err := client.GetDomainsV1DomainsGetWithResponse(ctx, &params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing domains: %v", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to read Domains. Status code: %d with error: %s", resp.StatusCode(), resp.Body)
+	}
+
+	return findDomainByName(*resp.JSON200, name), nil
+}
+
+func waapDomainRefreshFunc(ctx context.Context, client *waap.ClientWithResponses, name string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		domain, err := getDomainByName(ctx, client, name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if domain == nil {
+			// StateChangeConf treats a nil result as "not found", so return an empty struct instead
+			return &waap.SummaryDomainResponse{}, waapDomainStateAbsent, nil
+		}
+
+		return domain, waapDomainStatePresent, nil
+	}
+}
+
+func waitForWaapDomain(ctx context.Context, client *waap.ClientWithResponses, name string, timeout time.Duration) (*waap.SummaryDomainResponse, error) {
+	stateConf := retry.StateChangeConf{
+		Pending:    []string{waapDomainStateAbsent},
+		Target:     []string{waapDomainStatePresent},
+		Refresh:    waapDomainRefreshFunc(ctx, client, name),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*waap.SummaryDomainResponse), nil
+}
+
+// getCDNResourceWaap returns the CNAME of a CDN resource, which is used as the name of the corresponding WAAP domain,
+// and whether its WAAP option is enabled.
+func getCDNResourceWaap(ctx context.Context, config *Config, resourceID int64) (string, bool, error) {
+	result, err := config.CDNClient.Resources().Get(ctx, resourceID)
+	if err != nil {
+		return "", false, err
+	}
+
+	enabled := result.Options != nil && result.Options.WAAP != nil && result.Options.WAAP.Enabled && result.Options.WAAP.Value
+	return result.Cname, enabled, nil
+}
+
 func findDomainByName(response waap.PaginatedResponseSummaryDomainResponse, name string) *waap.SummaryDomainResponse {
 	for _, domain := range response.Results {
 		if strings.EqualFold(domain.Name, name) {
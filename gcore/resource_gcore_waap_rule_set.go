@@ -0,0 +1,324 @@
+package gcore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	waap "github.com/G-Core/gcore-waap-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// waapRuleSetDefinition is the payload materialised on every domain of a rule set attachment.
+// Rules are stored in the same shape as the WAAP API create requests.
+type waapRuleSetDefinition struct {
+	Name          string              `json:"name"`
+	CustomRules   []waap.CustomRule   `json:"custom_rules"`
+	FirewallRules []waap.FirewallRule `json:"firewall_rules"`
+	AdvancedRules []waap.AdvancedRule `json:"advanced_rules"`
+}
+
+func resourceWaapRuleSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWaapRuleSetCreate,
+		ReadContext:   resourceWaapRuleSetRead,
+		UpdateContext: resourceWaapRuleSetUpdate,
+		DeleteContext: resourceWaapRuleSetDelete,
+		CustomizeDiff: resourceWaapRuleSetCustomizeDiff,
+		Description: "Represent a reusable set of WAAP Custom, Firewall and Advanced Rules. " +
+			"The rule set only lives in the Terraform state, use `gcore_waap_rule_set_attachment` to apply it to domains.",
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the rule set.",
+			},
+			"custom_rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Custom Rules of the rule set. The arguments are the same as in `gcore_waap_custom_rule`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":        waapRuleSetNameSchema,
+						"description": waapRuleSetDescriptionSchema,
+						"enabled":     waapRuleSetEnabledSchema,
+						"action":      copySchemaWithoutConstraints(waapActionSchema),
+						"conditions":  copySchemaWithoutConstraints(resourceWaapCustomRule().Schema["conditions"]),
+					},
+				},
+			},
+			"firewall_rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Firewall Rules of the rule set. The arguments are the same as in `gcore_waap_firewall_rule`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":        waapRuleSetNameSchema,
+						"description": waapRuleSetDescriptionSchema,
+						"enabled":     waapRuleSetEnabledSchema,
+						"action":      copySchemaWithoutConstraints(resourceWaapFirewallRule().Schema["action"]),
+						"conditions":  copySchemaWithoutConstraints(resourceWaapFirewallRule().Schema["conditions"]),
+					},
+				},
+			},
+			"advanced_rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Advanced Rules of the rule set. The arguments are the same as in `gcore_waap_advanced_rule`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":        waapRuleSetNameSchema,
+						"description": waapRuleSetDescriptionSchema,
+						"enabled":     waapRuleSetEnabledSchema,
+						"action":      copySchemaWithoutConstraints(waapActionSchema),
+						"source":      copySchemaWithoutConstraints(resourceWaapAdvancedRule().Schema["source"]),
+						"phase":       copySchemaWithoutConstraints(resourceWaapAdvancedRule().Schema["phase"]),
+					},
+				},
+			},
+			"definition": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "JSON representation of the rule set as sent to the WAAP API. " +
+					"Pass it to `gcore_waap_rule_set_attachment.rule_set`.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 checksum of the rule set definition.",
+			},
+		},
+	}
+}
+
+var (
+	waapRuleSetNameSchema = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The name assigned to the rule.",
+	}
+	waapRuleSetDescriptionSchema = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "",
+		Description: "The description assigned to the rule.",
+	}
+	waapRuleSetEnabledSchema = &schema.Schema{
+		Type:        schema.TypeBool,
+		Required:    true,
+		Description: "Whether the rule is enabled.",
+	}
+)
+
+// copySchemaWithoutConstraints returns a deep copy of the schema with cross-attribute constraints removed.
+// Constraints such as ExactlyOneOf use absolute attribute paths and can't be reused in nested blocks,
+// they are checked by validateWaapRuleSetRules instead.
+func copySchemaWithoutConstraints(s *schema.Schema) *schema.Schema {
+	c := *s
+	c.ExactlyOneOf = nil
+	c.ConflictsWith = nil
+	c.AtLeastOneOf = nil
+	c.RequiredWith = nil
+
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			nested[k] = copySchemaWithoutConstraints(v)
+		}
+		c.Elem = &schema.Resource{Schema: nested}
+	case *schema.Schema:
+		c.Elem = copySchemaWithoutConstraints(elem)
+	}
+
+	return &c
+}
+
+// waapRuleSetExactlyOneOf lists, per rule kind, the nested blocks that must set exactly one of the attributes
+var waapRuleSetExactlyOneOf = []struct {
+	kind  string
+	block string
+	keys  []string
+}{
+	{"custom_rule", "action", []string{"allow", "block", "captcha", "handshake", "monitor", "tag"}},
+	{"firewall_rule", "action", []string{"allow", "block"}},
+	{"firewall_rule", "conditions", []string{"ip", "ip_range"}},
+	{"advanced_rule", "action", []string{"allow", "block", "captcha", "handshake", "monitor", "tag"}},
+}
+
+func resourceWaapRuleSetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := validateWaapRuleSetRules(d); err != nil {
+		return err
+	}
+
+	if d.HasChanges("custom_rule", "firewall_rule", "advanced_rule") {
+		if err := d.SetNewComputed("definition"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("version")
+	}
+
+	return nil
+}
+
+// validateWaapRuleSetRules applies the constraints of the single rule resources to every rule of the set
+func validateWaapRuleSetRules(d *schema.ResourceDiff) error {
+	for _, check := range waapRuleSetExactlyOneOf {
+		for i := range d.Get(check.kind).([]interface{}) {
+			prefix := fmt.Sprintf("%s.%d.%s.0", check.kind, i, check.block)
+			known, set := true, 0
+			for _, key := range check.keys {
+				path := prefix + "." + key
+				if !d.NewValueKnown(path) {
+					known = false
+					break
+				}
+				switch v := d.Get(path).(type) {
+				case bool:
+					if v {
+						set++
+					}
+				case []interface{}:
+					if len(v) > 0 {
+						set++
+					}
+				}
+			}
+			if known && set != 1 {
+				return fmt.Errorf("%s.%d.%s: exactly one of %s must be set", check.kind, i, check.block, strings.Join(check.keys, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceWaapRuleSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start WAAP Rule Set creating")
+
+	if err := setWaapRuleSetDefinition(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("name").(string))
+
+	log.Printf("[DEBUG] Finish WAAP Rule Set creating (id=%s)\n", d.Id())
+	return resourceWaapRuleSetRead(ctx, d, m)
+}
+
+func resourceWaapRuleSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The rule set has no API counterpart, everything is kept in the state
+	return nil
+}
+
+func resourceWaapRuleSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start WAAP Rule Set updating (id=%s)\n", d.Id())
+
+	if err := setWaapRuleSetDefinition(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finish WAAP Rule Set updating (id=%s)\n", d.Id())
+	return resourceWaapRuleSetRead(ctx, d, m)
+}
+
+func resourceWaapRuleSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func setWaapRuleSetDefinition(d *schema.ResourceData) error {
+	definition := waapRuleSetDefinition{
+		Name:          d.Get("name").(string),
+		CustomRules:   []waap.CustomRule{},
+		FirewallRules: []waap.FirewallRule{},
+		AdvancedRules: []waap.AdvancedRule{},
+	}
+
+	for _, item := range d.Get("custom_rule").([]interface{}) {
+		ruleMap := item.(map[string]interface{})
+		description := ruleMap["description"].(string)
+		rule := waap.CustomRule{
+			Name:        ruleMap["name"].(string),
+			Description: &description,
+			Enabled:     ruleMap["enabled"].(bool),
+			Conditions:  sortCustomRuleConditions(getConditionsPayload(ruleMap["conditions"])),
+		}
+		if action := getWaapActionPayload(ruleMap["action"]); action != nil {
+			rule.Action = *action
+		}
+		definition.CustomRules = append(definition.CustomRules, rule)
+	}
+
+	for _, item := range d.Get("firewall_rule").([]interface{}) {
+		ruleMap := item.(map[string]interface{})
+		description := ruleMap["description"].(string)
+		definition.FirewallRules = append(definition.FirewallRules, waap.FirewallRule{
+			Name:        ruleMap["name"].(string),
+			Description: &description,
+			Enabled:     ruleMap["enabled"].(bool),
+			Action:      parseFirewallActionBlock(ruleMap["action"].([]interface{})),
+			Conditions:  parseFirewallConditionBlock(ruleMap["conditions"].([]interface{})),
+		})
+	}
+
+	for _, item := range d.Get("advanced_rule").([]interface{}) {
+		ruleMap := item.(map[string]interface{})
+		description := ruleMap["description"].(string)
+		phase := waap.AdvancedRulePhase(ruleMap["phase"].(string))
+		rule := waap.AdvancedRule{
+			Name:        ruleMap["name"].(string),
+			Description: &description,
+			Enabled:     ruleMap["enabled"].(bool),
+			Source:      ruleMap["source"].(string),
+			Phase:       &phase,
+		}
+		if action := getWaapActionPayload(ruleMap["action"]); action != nil {
+			rule.Action = *action
+		}
+		definition.AdvancedRules = append(definition.AdvancedRules, rule)
+	}
+
+	raw, err := json.Marshal(definition)
+	if err != nil {
+		return fmt.Errorf("failed to serialize rule set: %w", err)
+	}
+
+	d.Set("definition", string(raw))
+	d.Set("version", waapRuleSetVersion(string(raw)))
+
+	return nil
+}
+
+// sortCustomRuleConditions orders conditions by their JSON form, since they are collected from a map
+// and the definition has to be stable between runs.
+func sortCustomRuleConditions(conditions []waap.CustomRuleConditionInput) []waap.CustomRuleConditionInput {
+	sort.SliceStable(conditions, func(i, j int) bool {
+		left, _ := json.Marshal(conditions[i])
+		right, _ := json.Marshal(conditions[j])
+		return string(left) < string(right)
+	})
+
+	return conditions
+}
+
+func waapRuleSetVersion(definition string) string {
+	hash := sha256.Sum256([]byte(definition))
+	return hex.EncodeToString(hash[:])
+}
+
+func validateWaapRuleSetDefinition(v interface{}, path string) ([]string, []error) {
+	var definition waapRuleSetDefinition
+	if err := json.Unmarshal([]byte(v.(string)), &definition); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a rule set definition: %w", path, err)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,513 @@
+package gcore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+
+	waap "github.com/G-Core/gcore-waap-sdk-go"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const waapRuleListPageSize = 100
+
+// waapRuleSetDomainState tracks the rules materialised on a single domain by a rule set attachment.
+type waapRuleSetDomainState struct {
+	DomainID        int
+	Version         string
+	CustomRuleIDs   []int
+	FirewallRuleIDs []int
+	AdvancedRuleIDs []int
+}
+
+func resourceWaapRuleSetAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWaapRuleSetAttachmentCreate,
+		ReadContext:   resourceWaapRuleSetAttachmentRead,
+		UpdateContext: resourceWaapRuleSetAttachmentUpdate,
+		DeleteContext: resourceWaapRuleSetAttachmentDelete,
+		CustomizeDiff: resourceWaapRuleSetAttachmentCustomizeDiff,
+		Description: "Represent a WAAP rule set applied to a list of domains. " +
+			"Every domain is converged independently and the rules applied to the other domains are kept when one fails. " +
+			"Domains that failed to converge on update are retried on the next apply, " +
+			"an attachment that failed on some domains on create is replaced on the next apply.",
+
+		Schema: map[string]*schema.Schema{
+			"rule_set": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateWaapRuleSetDefinition,
+				Description:  "Definition of the rule set to apply, usually `gcore_waap_rule_set.<name>.definition`.",
+			},
+			"domain_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The WAAP domain IDs the rule set is applied to.",
+			},
+			"domain": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules created by the attachment on every domain.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The WAAP domain ID.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the rule set applied to the domain.",
+						},
+						"custom_rule_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "IDs of the Custom Rules created on the domain.",
+						},
+						"firewall_rule_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "IDs of the Firewall Rules created on the domain.",
+						},
+						"advanced_rule_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "IDs of the Advanced Rules created on the domain.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceWaapRuleSetAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start WAAP Rule Set Attachment creating")
+
+	diags := convergeWaapRuleSetAttachment(ctx, d, m.(*Config).WaapClient)
+	if diags.HasError() && len(getWaapRuleSetDomains(d)) == 0 {
+		// Nothing was applied, there is nothing to track
+		return diags
+	}
+
+	// Keep the rules applied to the other domains in state, so that they are removed when the tainted
+	// attachment is replaced
+	d.SetId(uuid.New().String())
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finish WAAP Rule Set Attachment creating (id=%s)\n", d.Id())
+	return append(diags, resourceWaapRuleSetAttachmentRead(ctx, d, m)...)
+}
+
+func resourceWaapRuleSetAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start WAAP Rule Set Attachment reading (id=%s)\n", d.Id())
+
+	client := m.(*Config).WaapClient
+	domains := getWaapRuleSetDomains(d)
+
+	for i, domain := range domains {
+		exists, err := waapRuleSetDomainRulesExist(ctx, client, domain)
+		if err != nil {
+			return diag.Errorf("Failed to read rules of domain %d: %s", domain.DomainID, err)
+		}
+
+		if !exists {
+			// Some rules were removed outside of Terraform, force the domain to be converged again
+			log.Printf("[WARN] Rules of rule set attachment %s are missing on domain %d", d.Id(), domain.DomainID)
+			domains[i].Version = ""
+		}
+	}
+
+	d.Set("domain", flattenWaapRuleSetDomains(domains))
+
+	log.Printf("[DEBUG] Finish WAAP Rule Set Attachment reading (id=%s)\n", d.Id())
+	return nil
+}
+
+func resourceWaapRuleSetAttachmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start WAAP Rule Set Attachment updating (id=%s)\n", d.Id())
+
+	diags := convergeWaapRuleSetAttachment(ctx, d, m.(*Config).WaapClient)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finish WAAP Rule Set Attachment updating (id=%s)\n", d.Id())
+	return append(diags, resourceWaapRuleSetAttachmentRead(ctx, d, m)...)
+}
+
+func resourceWaapRuleSetAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start WAAP Rule Set Attachment deleting (id=%s)\n", d.Id())
+
+	client := m.(*Config).WaapClient
+	var diags diag.Diagnostics
+	remaining := make([]waapRuleSetDomainState, 0)
+
+	for _, domain := range getWaapRuleSetDomains(d) {
+		if err := deleteWaapRuleSetDomainRules(ctx, client, domain); err != nil {
+			diags = append(diags, waapRuleSetDomainDiag(domain.DomainID, "remove rules from", err))
+			remaining = append(remaining, domain)
+		}
+	}
+
+	if diags.HasError() {
+		d.Set("domain", flattenWaapRuleSetDomains(remaining))
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finish WAAP Rule Set Attachment deleting (id=%s)\n", d.Id())
+	d.SetId("")
+
+	return nil
+}
+
+// convergeWaapRuleSetAttachment brings every domain to the configured rule set version,
+// the new rules are created before the outdated ones are removed. Domains are handled independently: a failure on one domain is reported and doesn't stop the others,
+// and the state only records what was actually applied so that the next apply retries the failed domains.
+func convergeWaapRuleSetAttachment(ctx context.Context, d *schema.ResourceData, client *waap.ClientWithResponses) diag.Diagnostics {
+	var definition waapRuleSetDefinition
+	ruleSet := d.Get("rule_set").(string)
+	if err := json.Unmarshal([]byte(ruleSet), &definition); err != nil {
+		return diag.Errorf("Failed to parse rule set: %s", err)
+	}
+	version := waapRuleSetVersion(ruleSet)
+
+	desired := make(map[int]bool)
+	for _, v := range d.Get("domain_ids").(*schema.Set).List() {
+		desired[v.(int)] = true
+	}
+
+	var diags diag.Diagnostics
+	previous := make(map[int]waapRuleSetDomainState)
+	result := make([]waapRuleSetDomainState, 0, len(desired))
+
+	// Drop the rules from domains that are no longer attached
+	for _, domain := range getWaapRuleSetDomains(d) {
+		if desired[domain.DomainID] {
+			previous[domain.DomainID] = domain
+			continue
+		}
+
+		if err := deleteWaapRuleSetDomainRules(ctx, client, domain); err != nil {
+			diags = append(diags, waapRuleSetDomainDiag(domain.DomainID, "remove rules from", err))
+			result = append(result, domain)
+		}
+	}
+
+	domainIDs := make([]int, 0, len(desired))
+	for domainID := range desired {
+		domainIDs = append(domainIDs, domainID)
+	}
+	sort.Ints(domainIDs)
+
+	for _, domainID := range domainIDs {
+		old, attached := previous[domainID]
+		if attached && old.Version == version {
+			result = append(result, old)
+			continue
+		}
+
+		// Create the new rules before removing the outdated ones, so that the domain stays protected
+		domain, err := createWaapRuleSetDomainRules(ctx, client, domainID, definition)
+		if err != nil {
+			diags = append(diags, waapRuleSetDomainDiag(domainID, "apply rule set to", err))
+			// Roll back whatever was created, the outdated rules stay in place until the next apply
+			if err := deleteWaapRuleSetDomainRules(ctx, client, domain); err != nil {
+				diags = append(diags, waapRuleSetDomainDiag(domainID, "roll back rules on", err))
+				result = append(result, mergeWaapRuleSetDomainStates(domainID, old, domain))
+			} else if attached {
+				result = append(result, old)
+			}
+			continue
+		}
+
+		domain.Version = version
+		if err := deleteWaapRuleSetDomainRules(ctx, client, old); err != nil {
+			diags = append(diags, waapRuleSetDomainDiag(domainID, "remove outdated rules from", err))
+			// Track both copies so that the outdated rules are removed on the next apply
+			domain = mergeWaapRuleSetDomainStates(domainID, old, domain)
+		}
+		result = append(result, domain)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].DomainID < result[j].DomainID })
+	d.Set("domain", flattenWaapRuleSetDomains(result))
+
+	return diags
+}
+
+// resourceWaapRuleSetAttachmentCustomizeDiff plans an update whenever the tracked domains are not converged
+// to the configured rule set, e.g. after a partially failed apply or when rules were removed outside of Terraform.
+func resourceWaapRuleSetAttachmentCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("rule_set") || !d.NewValueKnown("domain_ids") {
+		return nil
+	}
+
+	version := waapRuleSetVersion(d.Get("rule_set").(string))
+	desired := make(map[int]bool)
+	for _, v := range d.Get("domain_ids").(*schema.Set).List() {
+		desired[v.(int)] = true
+	}
+
+	converged := 0
+	for _, item := range d.Get("domain").([]interface{}) {
+		domainMap := item.(map[string]interface{})
+		if !desired[domainMap["domain_id"].(int)] || domainMap["version"].(string) != version {
+			return d.SetNewComputed("domain")
+		}
+		converged++
+	}
+
+	if converged != len(desired) {
+		return d.SetNewComputed("domain")
+	}
+
+	return nil
+}
+
+func createWaapRuleSetDomainRules(ctx context.Context, client *waap.ClientWithResponses, domainID int, definition waapRuleSetDefinition) (waapRuleSetDomainState, error) {
+	domain := waapRuleSetDomainState{DomainID: domainID}
+
+	for _, rule := range definition.CustomRules {
+		resp, err := client.CreateCustomRuleV1DomainsDomainIdCustomRulesPostWithResponse(ctx, domainID, rule)
+		if err != nil {
+			return domain, fmt.Errorf("failed to create Custom Rule '%s': %w", rule.Name, err)
+		}
+		if resp.StatusCode() != http.StatusCreated {
+			return domain, fmt.Errorf("failed to create Custom Rule '%s'. Status code: %d with error: %s", rule.Name, resp.StatusCode(), resp.Body)
+		}
+		domain.CustomRuleIDs = append(domain.CustomRuleIDs, resp.JSON201.Id)
+	}
+
+	for _, rule := range definition.FirewallRules {
+		resp, err := client.CreateFirewallRuleV1DomainsDomainIdFirewallRulesPostWithResponse(ctx, domainID, rule)
+		if err != nil {
+			return domain, fmt.Errorf("failed to create Firewall Rule '%s': %w", rule.Name, err)
+		}
+		if resp.StatusCode() != http.StatusCreated {
+			return domain, fmt.Errorf("failed to create Firewall Rule '%s'. Status code: %d with error: %s", rule.Name, resp.StatusCode(), resp.Body)
+		}
+		domain.FirewallRuleIDs = append(domain.FirewallRuleIDs, resp.JSON201.Id)
+	}
+
+	for _, rule := range definition.AdvancedRules {
+		resp, err := client.CreateAdvancedRuleV1DomainsDomainIdAdvancedRulesPostWithResponse(ctx, domainID, rule)
+		if err != nil {
+			return domain, fmt.Errorf("failed to create Advanced Rule '%s': %w", rule.Name, err)
+		}
+		if resp.StatusCode() != http.StatusCreated {
+			return domain, fmt.Errorf("failed to create Advanced Rule '%s'. Status code: %d with error: %s", rule.Name, resp.StatusCode(), resp.Body)
+		}
+		domain.AdvancedRuleIDs = append(domain.AdvancedRuleIDs, resp.JSON201.Id)
+	}
+
+	return domain, nil
+}
+
+// deleteWaapRuleSetDomainRules removes the tracked rules from the domain, rules that are already gone are ignored.
+func deleteWaapRuleSetDomainRules(ctx context.Context, client *waap.ClientWithResponses, domain waapRuleSetDomainState) error {
+	for _, ruleID := range domain.CustomRuleIDs {
+		resp, err := client.DeleteCustomRuleV1DomainsDomainIdCustomRulesRuleIdDeleteWithResponse(ctx, domain.DomainID, ruleID)
+		if err != nil {
+			return fmt.Errorf("failed to delete Custom Rule %d: %w", ruleID, err)
+		}
+		if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("failed to delete Custom Rule %d. Status code: %d with error: %s", ruleID, resp.StatusCode(), resp.Body)
+		}
+	}
+
+	for _, ruleID := range domain.FirewallRuleIDs {
+		resp, err := client.DeleteFirewallRuleV1DomainsDomainIdFirewallRulesRuleIdDeleteWithResponse(ctx, domain.DomainID, ruleID)
+		if err != nil {
+			return fmt.Errorf("failed to delete Firewall Rule %d: %w", ruleID, err)
+		}
+		if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("failed to delete Firewall Rule %d. Status code: %d with error: %s", ruleID, resp.StatusCode(), resp.Body)
+		}
+	}
+
+	for _, ruleID := range domain.AdvancedRuleIDs {
+		resp, err := client.DeleteAdvancedRuleV1DomainsDomainIdAdvancedRulesRuleIdDeleteWithResponse(ctx, domain.DomainID, ruleID)
+		if err != nil {
+			return fmt.Errorf("failed to delete Advanced Rule %d: %w", ruleID, err)
+		}
+		if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("failed to delete Advanced Rule %d. Status code: %d with error: %s", ruleID, resp.StatusCode(), resp.Body)
+		}
+	}
+
+	return nil
+}
+
+// waapRuleSetDomainRulesExist checks that all the tracked rules are still present on the domain.
+func waapRuleSetDomainRulesExist(ctx context.Context, client *waap.ClientWithResponses, domain waapRuleSetDomainState) (bool, error) {
+	if len(domain.CustomRuleIDs) > 0 {
+		existing, err := collectWaapRuleIDs(func(limit, offset int) ([]int, error) {
+			params := waap.GetCustomRulesV1DomainsDomainIdCustomRulesGetParams{Limit: &limit, Offset: &offset}
+			resp, err := client.GetCustomRulesV1DomainsDomainIdCustomRulesGetWithResponse(ctx, domain.DomainID, &params)
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode() == http.StatusNotFound {
+				return nil, nil
+			}
+			if resp.StatusCode() != http.StatusOK {
+				return nil, fmt.Errorf("failed to list Custom Rules. Status code: %d with error: %s", resp.StatusCode(), resp.Body)
+			}
+			ids := make([]int, 0, len(resp.JSON200.Results))
+			for _, rule := range resp.JSON200.Results {
+				ids = append(ids, rule.Id)
+			}
+			return ids, nil
+		})
+		if err != nil || !containsAllInts(existing, domain.CustomRuleIDs) {
+			return false, err
+		}
+	}
+
+	if len(domain.FirewallRuleIDs) > 0 {
+		existing, err := collectWaapRuleIDs(func(limit, offset int) ([]int, error) {
+			params := waap.GetFirewallRulesV1DomainsDomainIdFirewallRulesGetParams{Limit: &limit, Offset: &offset}
+			resp, err := client.GetFirewallRulesV1DomainsDomainIdFirewallRulesGetWithResponse(ctx, domain.DomainID, &params)
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode() == http.StatusNotFound {
+				return nil, nil
+			}
+			if resp.StatusCode() != http.StatusOK {
+				return nil, fmt.Errorf("failed to list Firewall Rules. Status code: %d with error: %s", resp.StatusCode(), resp.Body)
+			}
+			ids := make([]int, 0, len(resp.JSON200.Results))
+			for _, rule := range resp.JSON200.Results {
+				ids = append(ids, rule.Id)
+			}
+			return ids, nil
+		})
+		if err != nil || !containsAllInts(existing, domain.FirewallRuleIDs) {
+			return false, err
+		}
+	}
+
+	if len(domain.AdvancedRuleIDs) > 0 {
+		existing, err := collectWaapRuleIDs(func(limit, offset int) ([]int, error) {
+			params := waap.GetAdvancedRulesV1DomainsDomainIdAdvancedRulesGetParams{Limit: &limit, Offset: &offset}
+			resp, err := client.GetAdvancedRulesV1DomainsDomainIdAdvancedRulesGetWithResponse(ctx, domain.DomainID, &params)
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode() == http.StatusNotFound {
+				return nil, nil
+			}
+			if resp.StatusCode() != http.StatusOK {
+				return nil, fmt.Errorf("failed to list Advanced Rules. Status code: %d with error: %s", resp.StatusCode(), resp.Body)
+			}
+			ids := make([]int, 0, len(resp.JSON200.Results))
+			for _, rule := range resp.JSON200.Results {
+				ids = append(ids, rule.Id)
+			}
+			return ids, nil
+		})
+		if err != nil || !containsAllInts(existing, domain.AdvancedRuleIDs) {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// collectWaapRuleIDs pages through a rule list endpoint and returns the IDs of all the rules found.
+func collectWaapRuleIDs(fetch func(limit, offset int) ([]int, error)) ([]int, error) {
+	result := make([]int, 0)
+	for offset := 0; ; offset += waapRuleListPageSize {
+		ids, err := fetch(waapRuleListPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, ids...)
+		if len(ids) < waapRuleListPageSize {
+			return result, nil
+		}
+	}
+}
+
+func containsAllInts(haystack, needles []int) bool {
+	for _, needle := range needles {
+		if !slices.Contains(haystack, needle) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeWaapRuleSetDomainStates tracks the rules of both states on the domain. The result has no version,
+// so that the domain is converged again and all of them are replaced.
+func mergeWaapRuleSetDomainStates(domainID int, states ...waapRuleSetDomainState) waapRuleSetDomainState {
+	result := waapRuleSetDomainState{DomainID: domainID}
+	for _, state := range states {
+		result.CustomRuleIDs = append(result.CustomRuleIDs, state.CustomRuleIDs...)
+		result.FirewallRuleIDs = append(result.FirewallRuleIDs, state.FirewallRuleIDs...)
+		result.AdvancedRuleIDs = append(result.AdvancedRuleIDs, state.AdvancedRuleIDs...)
+	}
+	return result
+}
+
+func waapRuleSetDomainDiag(domainID int, action string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Failed to %s domain %d", action, domainID),
+		Detail:   err.Error(),
+	}
+}
+
+func getWaapRuleSetDomains(d *schema.ResourceData) []waapRuleSetDomainState {
+	result := make([]waapRuleSetDomainState, 0)
+	for _, item := range d.Get("domain").([]interface{}) {
+		domainMap := item.(map[string]interface{})
+		result = append(result, waapRuleSetDomainState{
+			DomainID:        domainMap["domain_id"].(int),
+			Version:         domainMap["version"].(string),
+			CustomRuleIDs:   interfaceListToIntList(domainMap["custom_rule_ids"].([]interface{})),
+			FirewallRuleIDs: interfaceListToIntList(domainMap["firewall_rule_ids"].([]interface{})),
+			AdvancedRuleIDs: interfaceListToIntList(domainMap["advanced_rule_ids"].([]interface{})),
+		})
+	}
+	return result
+}
+
+func flattenWaapRuleSetDomains(domains []waapRuleSetDomainState) []interface{} {
+	result := make([]interface{}, 0, len(domains))
+	for _, domain := range domains {
+		result = append(result, map[string]interface{}{
+			"domain_id":         domain.DomainID,
+			"version":           domain.Version,
+			"custom_rule_ids":   domain.CustomRuleIDs,
+			"firewall_rule_ids": domain.FirewallRuleIDs,
+			"advanced_rule_ids": domain.AdvancedRuleIDs,
+		})
+	}
+	return result
+}
+
+func interfaceListToIntList(items []interface{}) []int {
+	result := make([]int, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.(int))
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package gcore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	waap "github.com/G-Core/gcore-waap-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceWaapRuleSetAttachmentCreatePartialFailure(t *testing.T) {
+	ruleSet := `{"name":"base","custom_rules":[{"name":"block","enabled":true,"action":{"block":{}},
+		"conditions":[{"ip":{"ip_address":"10.0.0.1"}}]}],"firewall_rules":[],"advanced_rules":[]}`
+
+	// Domain 1 accepts the rule, domain 2 rejects it
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/domains/1/custom-rules":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":11,"name":"block","enabled":true}`))
+		case "POST /v1/domains/2/custom-rules":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"detail":"invalid rule"}`))
+		default:
+			t.Errorf("unexpected call %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := waap.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceWaapRuleSetAttachment().Schema, map[string]interface{}{
+		"rule_set":   ruleSet,
+		"domain_ids": []interface{}{1, 2},
+	})
+	diags := resourceWaapRuleSetAttachmentCreate(context.Background(), d, &Config{WaapClient: client})
+
+	if !diags.HasError() || len(diags) != 1 || diags[0].Summary != "Failed to apply rule set to domain 2" {
+		t.Errorf("unexpected diagnostics: %+v", diags)
+	}
+	if d.Id() == "" {
+		t.Error("partially created attachment was not saved")
+	}
+	want := []waapRuleSetDomainState{{
+		DomainID:        1,
+		Version:         waapRuleSetVersion(ruleSet),
+		CustomRuleIDs:   []int{11},
+		FirewallRuleIDs: []int{},
+		AdvancedRuleIDs: []int{},
+	}}
+	if got := getWaapRuleSetDomains(d); !reflect.DeepEqual(got, want) {
+		t.Errorf("domain = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package gcore
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceWaapRuleSetCustomizeDiff(t *testing.T) {
+	ipCondition := map[string]interface{}{"ip_address": "10.0.0.1"}
+	ipRangeCondition := map[string]interface{}{"lower_bound": "10.0.0.1", "upper_bound": "10.0.0.9"}
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "valid",
+			config: map[string]interface{}{
+				"custom_rule": []interface{}{map[string]interface{}{
+					"name": "custom", "enabled": true,
+					"action":     []interface{}{map[string]interface{}{"captcha": true}},
+					"conditions": []interface{}{map[string]interface{}{"ip": []interface{}{ipCondition}}},
+				}},
+				"firewall_rule": []interface{}{map[string]interface{}{
+					"name": "firewall", "enabled": true,
+					"action":     []interface{}{map[string]interface{}{"allow": true}},
+					"conditions": []interface{}{map[string]interface{}{"ip_range": []interface{}{ipRangeCondition}}},
+				}},
+			},
+		},
+		{
+			name: "custom rule with two actions",
+			config: map[string]interface{}{
+				"custom_rule": []interface{}{map[string]interface{}{
+					"name": "custom", "enabled": true,
+					"action":     []interface{}{map[string]interface{}{"captcha": true, "monitor": true}},
+					"conditions": []interface{}{map[string]interface{}{"ip": []interface{}{ipCondition}}},
+				}},
+			},
+			wantErr: "custom_rule.0.action: exactly one of",
+		},
+		{
+			name: "firewall rule with two conditions",
+			config: map[string]interface{}{
+				"firewall_rule": []interface{}{map[string]interface{}{
+					"name": "firewall", "enabled": true,
+					"action": []interface{}{map[string]interface{}{"allow": true}},
+					"conditions": []interface{}{map[string]interface{}{
+						"ip":       []interface{}{ipCondition},
+						"ip_range": []interface{}{ipRangeCondition},
+					}},
+				}},
+			},
+			wantErr: "firewall_rule.0.conditions: exactly one of ip, ip_range must be set",
+		},
+		{
+			name: "advanced rule without action",
+			config: map[string]interface{}{
+				"advanced_rule": []interface{}{map[string]interface{}{
+					"name": "advanced", "enabled": true, "source": "request.ip == '10.0.0.1'",
+					"action": []interface{}{map[string]interface{}{}},
+				}},
+			},
+			wantErr: "advanced_rule.0.action: exactly one of",
+		},
+	}
+
+	ruleSetResource := resourceWaapRuleSet()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["name"] = "rule-set"
+			_, err := ruleSetResource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.config), nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package gcore
+
+import (
+	"fmt"
+	"net/http"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/snapshot/v1/snapshots"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type snapshotCopyOpts struct {
+	RegionID    int    `json:"region_id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// copySnapshot copies the snapshot to another region of the project
+func copySnapshot(client *gcorecloud.ServiceClient, snapshotID string, opts snapshotCopyOpts) (r tasks.Result) {
+	_, r.Err = client.Post(client.ServiceURL(snapshotID, "copy"), opts, &r.Body, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK, http.StatusCreated},
+	})
+	return
+}
+
+// createSnapshotClientInRegion creates a snapshots client for the project of the resource and the given region
+func createSnapshotClientInRegion(provider *gcorecloud.ProviderClient, d *schema.ResourceData, regionID int) (*gcorecloud.ServiceClient, error) {
+	projectID, err := GetProject(provider, d.Get("project_id").(int), d.Get("project_name").(string))
+	if err != nil {
+		return nil, err
+	}
+	return gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    snapshotsPoint,
+		Region:  regionID,
+		Project: projectID,
+		Version: versionPointV1,
+	})
+}
+
+// latestVolumeSnapshot returns the most recent snapshot of the volume, nil if the volume has no snapshots
+func latestVolumeSnapshot(client *gcorecloud.ServiceClient, volumeID string) (*snapshots.Snapshot, error) {
+	volumeSnapshots, err := snapshots.ListAll(client, snapshots.ListOpts{VolumeID: volumeID})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list snapshots of volume %s: %w", volumeID, err)
+	}
+	var latest *snapshots.Snapshot
+	for i := range volumeSnapshots {
+		if latest == nil || volumeSnapshots[i].CreatedAt.After(latest.CreatedAt.Time) {
+			latest = &volumeSnapshots[i]
+		}
+	}
+	return latest, nil
+}
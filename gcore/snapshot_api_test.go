@@ -0,0 +1,45 @@
+package gcore
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+func TestLatestVolumeSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("volume_id"); got != "vol" {
+			t.Errorf("volume_id = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":3,"results":[
+			{"id":"old","volume_id":"vol","created_at":"2024-01-01T10:00:00+0000"},
+			{"id":"new","volume_id":"vol","created_at":"2024-03-01T10:00:00+0000"},
+			{"id":"mid","volume_id":"vol","created_at":"2024-02-01T10:00:00+0000"}]}`))
+	}))
+	defer server.Close()
+
+	client := &gcorecloud.ServiceClient{ProviderClient: &gcorecloud.ProviderClient{}, Endpoint: server.URL + "/"}
+	latest, err := latestVolumeSnapshot(client, "vol")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest == nil || latest.ID != "new" {
+		t.Errorf("unexpected latest snapshot: %+v", latest)
+	}
+}
+
+func TestCopySnapshot(t *testing.T) {
+	client := newAPIFixtureClient(t, apiFixture{
+		method: http.MethodPost, path: "/snap/copy", status: http.StatusOK,
+		request:  `{"region_id":76,"name":"dr copy"}`,
+		response: `{"tasks":["t1"]}`,
+	})
+
+	results, err := copySnapshot(client, "snap", snapshotCopyOpts{RegionID: 76, Name: "dr copy"}).Extract()
+	if err != nil || len(results.Tasks) != 1 || results.Tasks[0] != "t1" {
+		t.Errorf("copySnapshot = %+v, %v", results, err)
+	}
+}
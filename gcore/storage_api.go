@@ -0,0 +1,91 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	storageclient "github.com/G-Core/gcore-storage-sdk-go/swagger/client"
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/client/location"
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/client/statistics"
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/models"
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// storageAPI calls storage API endpoints not wrapped by the storage SDK (locations, statistics)
+type storageAPI struct {
+	client *storageclient.GCDNStorageAPI
+	auth   runtime.ClientAuthInfoWriter
+}
+
+// newStorageAPI returns a client for the storage API at apiHost/basePath, as configured for the storage SDK
+func newStorageAPI(apiHost, basePath string, auth runtime.ClientAuthInfoWriter) *storageAPI {
+	var schemes []string
+	if scheme, host, ok := strings.Cut(apiHost, "://"); ok {
+		apiHost, schemes = host, []string{scheme}
+	}
+	return &storageAPI{
+		client: storageclient.New(httptransport.New(apiHost, basePath, schemes), strfmt.Default),
+		auth:   auth,
+	}
+}
+
+func (s *storageAPI) locations(ctx context.Context) ([]*models.ClientLocationRes, error) {
+	rsp, err := s.client.Location.LocationListHTTP(&location.LocationListHTTPParams{Context: ctx}, s.auth)
+	if err != nil {
+		return nil, fmt.Errorf("storage locations list: %w", err)
+	}
+	return rsp.Payload, nil
+}
+
+func (s *storageAPI) usage(ctx context.Context, body statistics.StorageUsageSeriesHTTPPostBody) (*models.StorageUsageSeriesServiceRes, error) {
+	params := &statistics.StorageUsageSeriesHTTPPostParams{Context: ctx, Body: body}
+	rsp, err := s.client.Statistics.StorageUsageSeriesHTTPPost(params, s.auth)
+	if err != nil {
+		return nil, fmt.Errorf("storage usage: %w", err)
+	}
+	if rsp.Payload == nil || rsp.Payload.Data == nil {
+		return &models.StorageUsageSeriesServiceRes{}, nil
+	}
+	return rsp.Payload.Data, nil
+}
+
+// validateStorageLocationDiff checks the location of a new storage at plan time
+func validateStorageLocationDiff(storageType string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		config, ok := m.(*Config)
+		if !ok || config.StorageAPI == nil || !diff.HasChange(StorageSchemaLocation) || !diff.NewValueKnown(StorageSchemaLocation) {
+			return nil
+		}
+		locations, err := config.StorageAPI.locations(ctx)
+		if err != nil {
+			return err
+		}
+		return checkStorageLocation(locations, diff.Get(StorageSchemaLocation).(string), storageType)
+	}
+}
+
+// checkStorageLocation checks that name is a location of the storage type, available for new storages
+func checkStorageLocation(locations []*models.ClientLocationRes, name, storageType string) error {
+	available := make([]string, 0, len(locations))
+	for _, l := range locations {
+		if l == nil || (l.Type != "" && l.Type != storageType) {
+			continue
+		}
+		if l.Name == name {
+			if l.AllowForNewStorage == "deny" {
+				return fmt.Errorf("storage location %s is not available for new storages", name)
+			}
+			return nil
+		}
+		if l.AllowForNewStorage != "deny" {
+			available = append(available, l.Name)
+		}
+	}
+	sort.Strings(available)
+	return fmt.Errorf("%q is not a %s storage location, available locations: %s", name, storageType, strings.Join(available, ", "))
+}
@@ -0,0 +1,94 @@
+package gcore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+func TestStorageAPILocations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/location") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "APIKey token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":1,"name":"s-ed1","type":"s3","address":"s-ed1.cloud.gcore.lu","allow_for_new_storage":"allow"}]`))
+	}))
+	defer server.Close()
+
+	api := newStorageAPI(server.URL, "/storage", runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+		return r.SetHeaderParam("Authorization", "APIKey token")
+	}))
+	locations, err := api.locations(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(locations) != 1 || locations[0].Name != "s-ed1" || locations[0].Type != "s3" {
+		t.Errorf("unexpected locations: %+v", locations)
+	}
+}
+
+func TestCheckStorageLocation(t *testing.T) {
+	locations := []*models.ClientLocationRes{
+		{Name: "s-ed1", Type: "s3", AllowForNewStorage: "allow"},
+		{Name: "s-ws1", Type: "s3", AllowForNewStorage: "deny"},
+		{Name: "mia", Type: "sftp", AllowForNewStorage: "allow"},
+		{Name: "ams", Type: "sftp", AllowForNewStorage: "allow"},
+	}
+	tests := []struct {
+		name        string
+		location    string
+		storageType string
+		wantErr     string
+	}{
+		{name: "available", location: "s-ed1", storageType: "s3"},
+		{name: "full", location: "s-ws1", storageType: "s3", wantErr: "not available for new storages"},
+		{name: "wrong type", location: "mia", storageType: "s3", wantErr: `"mia" is not a s3 storage location, available locations: s-ed1`},
+		{name: "unknown", location: "nowhere", storageType: "sftp", wantErr: "available locations: ams, mia"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStorageLocation(locations, tt.location, tt.storageType)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFlattenStorageUsage(t *testing.T) {
+	result := &models.StorageUsageSeriesServiceRes{Clients: map[string]models.ClientStats{
+		"1": {Locations: map[string]models.LocationStats{
+			"s-ed1": {Name: "s-ed1", Storages: map[string]models.StorageStats{
+				"1-b": {Name: "1-b", SizeSumMax: 10, FileQuantitySumMax: 2, TrafficSum: 7, TrafficOutEdgesSum: 3, TrafficOutWoEdgesSum: 4},
+				"1-a": {Name: "1-a", SizeSumMax: 20},
+			}},
+		}},
+	}}
+	usage := flattenStorageUsage(result)
+	if len(usage) != 2 {
+		t.Fatalf("expected 2 storages, got %d", len(usage))
+	}
+	first, second := usage[0].(map[string]interface{}), usage[1].(map[string]interface{})
+	if first["name"] != "1-a" || second["name"] != "1-b" {
+		t.Errorf("unexpected order: %v, %v", first["name"], second["name"])
+	}
+	if second["objects"] != 2 || second["traffic_out"] != 7 || second[StorageSchemaLocation] != "s-ed1" {
+		t.Errorf("unexpected usage: %v", second)
+	}
+}
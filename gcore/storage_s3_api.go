@@ -0,0 +1,331 @@
+package gcore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/G-Core/gcore-storage-sdk-go/swagger/client/storage"
+)
+
+// s3Client is a minimal S3 API client, used for bucket and object settings not covered by the storage API.
+// Requests are path-style and signed with AWS Signature Version 4 using the storage credentials.
+type s3Client struct {
+	endpoint  string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// s3HTTPClient is shared by the S3 clients, so that a stalled endpoint fails the request instead of hanging Terraform
+var s3HTTPClient = &http.Client{Timeout: 5 * time.Minute}
+
+// storageS3Host returns the S3 host of the storage location
+func storageS3Host(location string) string {
+	return fmt.Sprintf("%s.cloud.gcore.lu", location)
+}
+
+// newS3Client returns a client for the S3 endpoint of the storage location
+func newS3Client(location, accessKey, secretKey string) *s3Client {
+	return &s3Client{
+		endpoint:  "https://" + storageS3Host(location),
+		region:    location,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    s3HTTPClient,
+	}
+}
+
+// s3Error is an S3 API error response
+type s3Error struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *s3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("S3 API: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("S3 API: %s: %s (status %d)", e.Code, e.Message, e.StatusCode)
+}
+
+// isS3NotFound checks for missing bucket settings (NoSuchCORSConfiguration, NoSuchBucketPolicy, etc.) or objects,
+// a missing bucket is not included
+func isS3NotFound(err error) bool {
+	s3err, ok := err.(*s3Error)
+	return ok && s3err.StatusCode == http.StatusNotFound && s3err.Code != "NoSuchBucket"
+}
+
+// do sends a request to bucket/key, query holds subresources like "cors" with empty values
+func (c *s3Client) do(ctx context.Context, method, bucket, key string, query url.Values, header http.Header, body []byte) ([]byte, http.Header, error) {
+	path := "/" + s3URIEncode(bucket, true)
+	if key != "" {
+		path += "/" + s3URIEncode(key, false)
+	}
+	rawURL := c.endpoint + path
+	if len(query) > 0 {
+		rawURL += "?" + s3CanonicalQuery(query)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		hash := md5.Sum(body)
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(hash[:]))
+	}
+	c.sign(req, body, time.Now())
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("S3 API: %w", err)
+	}
+	defer rsp.Body.Close()
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("S3 API: reading response: %w", err)
+	}
+	if rsp.StatusCode >= 300 {
+		s3err := &s3Error{StatusCode: rsp.StatusCode}
+		_ = xml.Unmarshal(data, s3err)
+		return nil, nil, s3err
+	}
+	return data, rsp.Header, nil
+}
+
+// getBucketSubresource returns bucket settings, found is false if the settings are not configured
+func (c *s3Client) getBucketSubresource(ctx context.Context, bucket, subresource string) (data []byte, found bool, err error) {
+	data, _, err = c.do(ctx, http.MethodGet, bucket, "", url.Values{subresource: {""}}, nil, nil)
+	if isS3NotFound(err) {
+		return nil, false, nil
+	}
+	return data, err == nil, err
+}
+
+func (c *s3Client) putBucketSubresource(ctx context.Context, bucket, subresource string, body []byte) error {
+	_, _, err := c.do(ctx, http.MethodPut, bucket, "", url.Values{subresource: {""}}, nil, body)
+	return err
+}
+
+func (c *s3Client) deleteBucketSubresource(ctx context.Context, bucket, subresource string) error {
+	_, _, err := c.do(ctx, http.MethodDelete, bucket, "", url.Values{subresource: {""}}, nil, nil)
+	if isS3NotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *s3Client) putObject(ctx context.Context, bucket, key string, header http.Header, body []byte) (http.Header, error) {
+	_, rspHeader, err := c.do(ctx, http.MethodPut, bucket, key, nil, header, body)
+	return rspHeader, err
+}
+
+// headObject returns object headers, found is false if the object does not exist
+func (c *s3Client) headObject(ctx context.Context, bucket, key string) (header http.Header, found bool, err error) {
+	_, header, err = c.do(ctx, http.MethodHead, bucket, key, nil, nil, nil)
+	if isS3NotFound(err) {
+		return nil, false, nil
+	}
+	return header, err == nil, err
+}
+
+func (c *s3Client) deleteObject(ctx context.Context, bucket, key string) error {
+	_, _, err := c.do(ctx, http.MethodDelete, bucket, key, nil, nil, nil)
+	if isS3NotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// listObjects returns all objects with the key prefix, following continuation tokens
+func (c *s3Client) listObjects(ctx context.Context, bucket, prefix string) ([]s3Object, error) {
+	var objects []s3Object
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	for {
+		data, _, err := c.do(ctx, http.MethodGet, bucket, "", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result s3ListBucketResult
+		if err := xml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("S3 API: decoding object list: %w", err)
+		}
+		objects = append(objects, result.Contents...)
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// sign adds AWS Signature Version 4 headers, all request headers are signed
+func (c *s3Client) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256.Sum256(body)
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		values := make([]string, len(v))
+		for i := range v {
+			values[i] = strings.Join(strings.Fields(v[i]), " ")
+		}
+		headers[strings.ToLower(k)] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := amzDate[:8] + "/" + c.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + c.secretKey)
+	for _, part := range []string{amzDate[:8], c.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3CanonicalQuery sorts and encodes query parameters as required for signing
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for _, k := range keys {
+		values := append([]string{}, query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3URIEncode(k, true)+"="+s3URIEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3URIEncode encodes everything except unreserved characters, and slashes unless encodeSlash is set
+func s3URIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// S3 bucket settings documents
+
+type s3CORSConfiguration struct {
+	XMLName xml.Name     `xml:"CORSConfiguration"`
+	Rules   []s3CORSRule `xml:"CORSRule"`
+}
+
+type s3CORSRule struct {
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+type s3LifecycleConfiguration struct {
+	XMLName xml.Name          `xml:"LifecycleConfiguration"`
+	Rules   []s3LifecycleRule `xml:"Rule"`
+}
+
+type s3LifecycleRule struct {
+	ID     string             `xml:"ID,omitempty"`
+	Filter *s3LifecycleFilter `xml:"Filter,omitempty"`
+	// Prefix is the legacy rule filter, only read
+	Prefix     string `xml:"Prefix,omitempty"`
+	Status     string `xml:"Status"`
+	Expiration struct {
+		Days int `xml:"Days"`
+	} `xml:"Expiration"`
+}
+
+type s3LifecycleFilter struct {
+	Prefix string `xml:"Prefix"`
+}
+
+// S3 object listing
+
+type s3ListBucketResult struct {
+	XMLName               xml.Name   `xml:"ListBucketResult"`
+	Contents              []s3Object `xml:"Contents"`
+	IsTruncated           bool       `xml:"IsTruncated"`
+	NextContinuationToken string     `xml:"NextContinuationToken"`
+}
+
+type s3Object struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+}
+
+// newStorageS3Client returns an S3 API client for the storage, located by its id
+func newStorageS3Client(ctx context.Context, config *Config, storageID int, accessKey, secretKey string) (*s3Client, error) {
+	id := fmt.Sprint(storageID)
+	result, err := config.StorageClient.StoragesList(func(opt *storage.StorageListHTTPV2Params) {
+		opt.Context = ctx
+		opt.ID = &id
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storages list: %w", err)
+	}
+	if len(result) != 1 {
+		return nil, fmt.Errorf("get storage: wrong length of search result (%d), want 1", len(result))
+	}
+	return newS3Client(result[0].Location, accessKey, secretKey), nil
+}
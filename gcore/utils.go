@@ -62,6 +62,7 @@ type Config struct {
 	CDNClient      gcdn.ClientService
 	CDNMutex       *sync.Mutex
 	StorageClient  *storageSDK.SDK
+	StorageAPI     *storageAPI
 	DNSClient      *dnssdk.Client
 	FastEdgeClient *fastedge.ClientWithResponses
 	WaapClient     *waap.ClientWithResponses
@@ -0,0 +1,20 @@
+package gcore
+
+import (
+	"strconv"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+// getRegionVolumeTypes returns the volume types available in the region,
+// regions.GetOpts has ShowVolumeTypes but doesn't send it as a query parameter
+func getRegionVolumeTypes(client *gcorecloud.ServiceClient, regionID int) ([]string, error) {
+	var region struct {
+		AvailableVolumeTypes []string `json:"available_volume_types"`
+	}
+	url := client.ServiceURL(strconv.Itoa(regionID)) + "?show_volume_types=true"
+	if _, err := client.Get(url, &region, nil); err != nil {
+		return nil, err
+	}
+	return region.AvailableVolumeTypes, nil
+}
@@ -0,0 +1,19 @@
+package gcore
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestGetRegionVolumeTypes(t *testing.T) {
+	client := newAPIFixtureClient(t, apiFixture{
+		method: http.MethodGet, path: "/76", query: "show_volume_types=true", status: http.StatusOK,
+		response: `{"id":76,"display_name":"Luxembourg","available_volume_types":["standard","ssd_hiiops","cold"]}`,
+	})
+
+	types, err := getRegionVolumeTypes(client, 76)
+	if err != nil || !slices.Equal(types, []string{"standard", "ssd_hiiops", "cold"}) {
+		t.Errorf("getRegionVolumeTypes = %v, %v", types, err)
+	}
+}
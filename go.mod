@@ -12,11 +12,15 @@ require (
 	github.com/G-Core/gcore-waap-sdk-go v0.5.0
 	github.com/G-Core/gcorelabscdn-go v1.0.36
 	github.com/G-Core/gcorelabscloud-go v0.32.0
+	github.com/go-openapi/runtime v0.26.0
+	github.com/go-openapi/strfmt v0.21.7
 	github.com/google/go-cmp v0.7.0
 	github.com/google/uuid v1.6.0
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.27.0
 	github.com/mitchellh/mapstructure v1.5.0
+	github.com/tetratelabs/wazero v1.9.0
+	golang.org/x/crypto v0.38.0
 )
 
 require (
@@ -48,7 +52,6 @@ require (
 	github.com/go-openapi/jsonreference v0.20.2 // indirect
 	github.com/go-openapi/loads v0.21.2 // indirect
 	github.com/go-openapi/spec v0.20.9 // indirect
-	github.com/go-openapi/strfmt v0.21.7 // indirect
 	github.com/go-openapi/swag v0.23.1 // indirect
 	github.com/go-openapi/validate v0.22.1 // indirect
 	github.com/go-playground/locales v0.14.1 // indirect
@@ -114,7 +117,6 @@ require (
 	go.opentelemetry.io/otel v1.16.0 // indirect
 	go.opentelemetry.io/otel/metric v1.16.0 // indirect
 	go.opentelemetry.io/otel/trace v1.16.0 // indirect
-	golang.org/x/crypto v0.38.0 // indirect
 	golang.org/x/exp v0.0.0-20230626212559-97b1e661b5df // indirect
 	golang.org/x/mod v0.25.0 // indirect
 	golang.org/x/sync v0.15.0 // indirect
@@ -129,7 +131,6 @@ require (
 )
 
 require (
-	github.com/go-openapi/runtime v0.26.0 // indirect
 	github.com/hashicorp/terraform v1.5.7
 	github.com/hashicorp/terraform-exec v0.23.0 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect